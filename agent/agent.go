@@ -1,18 +1,24 @@
 package agent
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/local"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sirupsen/logrus"
 	"github.com/stellarproject/element"
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
 	"github.com/stellarproject/terra/client"
 	bolt "go.etcd.io/bbolt"
 	"google.golang.org/grpc"
@@ -22,6 +28,10 @@ import (
 const (
 	bucketState           = "io.stellarproject.terra.v1.state"
 	bucketAssemblies      = "io.stellarproject.terra.v1.assemblies"
+	bucketRevisions       = "io.stellarproject.terra.v1.revisions"
+	bucketExecutions      = "io.stellarproject.terra.v1.executions"
+	bucketSecrets         = "io.stellarproject.terra.v1.secrets"
+	bucketImages          = "io.stellarproject.terra.v1.images"
 	keyManifestList       = "manifest-list"
 	cacheFilename         = "peers.json"
 	dsLocalPeerBucketName = "peers"
@@ -35,13 +45,20 @@ type status struct {
 	mu          *sync.Mutex
 	state       api.NodeStatus_Status
 	description string
+	assemblies  map[string]*api.AssemblyStatus
+	inflight    map[string]bool
+	rules       map[string]string
 }
 
 func (s *status) State() api.NodeStatus_Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.state
 }
 
 func (s *status) Description() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.description
 }
 
@@ -53,7 +70,92 @@ func (s *status) Set(state api.NodeStatus_Status, desc string) {
 }
 
 func (s *status) IsUpdating() bool {
-	return s.state == api.NodeStatus_UPDATING
+	return s.State() == api.NodeStatus_UPDATING
+}
+
+// StartAssembly marks the assembly as in flight
+func (s *status) StartAssembly(image string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inflight[image] = true
+	s.updateInflight()
+}
+
+// FinishAssembly marks the assembly as no longer in flight
+func (s *status) FinishAssembly(image string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inflight, image)
+	s.updateInflight()
+}
+
+// updateInflight sets the description to the in flight assemblies; the lock must be held
+func (s *status) updateInflight() {
+	s.state = api.NodeStatus_UPDATING
+	if len(s.inflight) == 0 {
+		s.description = ""
+		return
+	}
+	images := []string{}
+	for image := range s.inflight {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	s.description = fmt.Sprintf("applying %s", strings.Join(images, ", "))
+}
+
+// SetAssembly records the result of the last operation for the assembly
+func (s *status) SetAssembly(assembly *api.AssemblyStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if assembly.Rule == "" {
+		assembly.Rule = s.rules[assembly.Image]
+	}
+	s.assemblies[assembly.Image] = assembly
+}
+
+// SetRules sets the manifest rules that selected the assemblies by image
+func (s *status) SetRules(rules map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = rules
+}
+
+// Assembly returns the result of the last operation for the assembly or nil
+func (s *status) Assembly(image string) *api.AssemblyStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.assemblies[image]
+}
+
+// ResetAssemblies clears the assembly results from a previous apply
+func (s *status) ResetAssemblies() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assemblies = map[string]*api.AssemblyStatus{}
+}
+
+// Assemblies returns the assembly results sorted by image
+func (s *status) Assemblies() []*api.AssemblyStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assemblies := []*api.AssemblyStatus{}
+	for _, a := range s.assemblies {
+		assemblies = append(assemblies, a)
+	}
+	sort.Slice(assemblies, func(i, j int) bool {
+		return assemblies[i].Image < assemblies[j].Image
+	})
+	return assemblies
+}
+
+// NodeStatus returns the api representation of the current status
+func (s *status) NodeStatus() *api.NodeStatus {
+	return &api.NodeStatus{
+		Status:      s.State(),
+		Description: s.Description(),
+		Assemblies:  s.Assemblies(),
+	}
 }
 
 type Agent struct {
@@ -62,9 +164,20 @@ type Agent struct {
 	clusterAgent *element.Agent
 	mu           *sync.Mutex
 	muCache      *sync.Mutex
+	muRollout    *sync.Mutex
+	muApply      *sync.Mutex
+	muCancel     *sync.Mutex
+	cancelApply  context.CancelFunc
 	manifestList *api.ManifestList
 	db           *bolt.DB
 	status       *status
+	logs         *logHub
+	retries      *retries
+	muFacts      *sync.Mutex
+	nodeFacts    map[string]string
+	muContent    *sync.RWMutex
+	contentStore content.Store
+	cgroupSlice  *cgroupSlice
 }
 
 type AgentConfig struct {
@@ -76,6 +189,19 @@ type AgentConfig struct {
 	Labels                map[string]string
 	ConnectionType        string
 	DataDir               string
+	ApplyConcurrency      int
+	FetchConcurrency      int
+	DisablePeerFetch      bool
+	RevisionHistory       int
+	ExecutionHistory      int
+	ReconcileInterval     time.Duration
+	FactsInterval         time.Duration
+	DriftPolicy           string
+	AssemblyTimeout       time.Duration
+	AssemblyStopTimeout   time.Duration
+	CgroupSlice           string
+	Resources             *api.ResourceLimits
+	SecretKey             []byte
 	TLSServerCertificate  string
 	TLSServerKey          string
 	TLSInsecureSkipVerify bool
@@ -113,7 +239,7 @@ func NewAgent(cfg *AgentConfig) (*Agent, error) {
 		return nil, err
 	}
 	if err := db.Update(func(tx *bolt.Tx) error {
-		for _, b := range []string{bucketState, bucketAssemblies} {
+		for _, b := range []string{bucketState, bucketAssemblies, bucketRevisions, bucketExecutions, bucketSecrets, bucketImages} {
 			if _, err := tx.CreateBucketIfNotExists([]byte(b)); err != nil {
 				return err
 			}
@@ -123,6 +249,23 @@ func NewAgent(cfg *AgentConfig) (*Agent, error) {
 		return nil, err
 	}
 
+	// image content is shared by assemblies and kept across applies
+	cs, err := local.NewStore(filepath.Join(cfg.DataDir, contentDir))
+	if err != nil {
+		return nil, err
+	}
+
+	// execution directories are left by entrypoints interrupted by a restart
+	if err := os.RemoveAll(filepath.Join(cfg.DataDir, workDir)); err != nil {
+		return nil, err
+	}
+
+	// controllers are enabled for the execution groups once
+	slice, err := setupCgroupSlice(cfg.CgroupSlice)
+	if err != nil {
+		logrus.WithError(err).Warnf("running assemblies without cgroup slice %s", cfg.CgroupSlice)
+	}
+
 	// check for peers
 	peers, err := getPeersFromCache(db, cfg.Peers)
 	if err != nil {
@@ -153,12 +296,24 @@ func NewAgent(cfg *AgentConfig) (*Agent, error) {
 		clusterAgent: agt,
 		mu:           &sync.Mutex{},
 		muCache:      &sync.Mutex{},
+		muRollout:    &sync.Mutex{},
+		muApply:      &sync.Mutex{},
+		muCancel:     &sync.Mutex{},
 		db:           db,
+		logs:         newLogHub(cfg.NodeID),
+		retries:      newRetries(),
+		muFacts:      &sync.Mutex{},
+		muContent:    &sync.RWMutex{},
+		contentStore: cs,
+		cgroupSlice:  slice,
 		status: &status{
-			mu:    &sync.Mutex{},
-			state: api.NodeStatus_OK,
+			mu:         &sync.Mutex{},
+			state:      api.NodeStatus_OK,
+			assemblies: map[string]*api.AssemblyStatus{},
+			inflight:   map[string]bool{},
 		},
 	}
+	agent.updateFacts()
 	api.RegisterTerraServer(grpcServer, agent)
 
 	nodeEventCh := agt.Subscribe()
@@ -184,6 +339,15 @@ func (a *Agent) Start() error {
 	}
 
 	go a.sync()
+	go a.retry()
+
+	if a.config.ReconcileInterval > 0 {
+		go a.reconcile()
+	}
+
+	if a.config.FactsInterval > 0 {
+		go a.refreshFacts()
+	}
 
 	return nil
 }
@@ -244,6 +408,10 @@ func (a *Agent) syncWithPeers() error {
 			continue
 		}
 
+		if err := a.syncSecrets(c); err != nil {
+			logrus.Errorf("error syncing secrets with peer %s: %s", peer.ID, err)
+		}
+
 		ml, err := c.List()
 		if err != nil {
 			logrus.Errorf("error getting peer manifest list: %s", err)
@@ -258,8 +426,15 @@ func (a *Agent) syncWithPeers() error {
 		logrus.Debugf("peer %s manifest list updated %s", peer.ID, ml.Updated)
 		if ml.Updated.After(updated) {
 			logrus.Debugf("updating local manifest from peer %s", peer.ID)
+			// replicate revision history
+			history, err := c.History()
+			if err != nil {
+				logrus.Errorf("error getting peer manifest list history: %s", err)
+			} else if err := a.storeRevisions(history); err != nil {
+				logrus.Errorf("error storing peer manifest list history: %s", err)
+			}
 			// sync local payload
-			if err := a.updateManifestList(ml, false); err != nil {
+			if err := a.updateManifestList(ml, false, api.Execution_SYNC); err != nil {
 				logrus.Errorf("error syncing manifest list with peer: %s", err)
 				c.Close()
 				continue
@@ -296,5 +471,7 @@ func (a *Agent) restoreState() error {
 		return err
 	}
 
-	return nil
+	a.manifestList = ml
+
+	return a.restoreAssemblyStatus()
 }
@@ -0,0 +1,131 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+	api "github.com/stellarproject/terra/api/v1"
+	bolt "go.etcd.io/bbolt"
+)
+
+// assemblyRecord is the record of an applied assembly stored in bucketAssemblies
+type assemblyRecord struct {
+	Image          string        `json:"image"`
+	Digest         digest.Digest `json:"digest"`
+	ParametersHash string        `json:"parameters_hash"`
+	Applied        time.Time     `json:"applied"`
+	Output         []byte        `json:"output"`
+}
+
+// current returns true if the record matches the resolved digest and parameters hash
+func (r *assemblyRecord) current(dgst digest.Digest, paramsHash string) bool {
+	return r.Digest == dgst && r.ParametersHash == paramsHash
+}
+
+// assemblyParametersHash returns the parameters hash of the assembly including
+// the versions of referenced secrets
+func (a *Agent) assemblyParametersHash(assembly *api.Assembly) string {
+	return parametersHash(a.parameterVersions(assembly.Parameters))
+}
+
+// parametersHash returns a stable hash of the assembly parameters
+func parametersHash(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s\x00", k, params[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// getAssemblyRecord returns the record for the applied image or nil if not applied
+func (a *Agent) getAssemblyRecord(image string) (*assemblyRecord, error) {
+	var record *assemblyRecord
+	if err := a.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketAssemblies))
+		v := b.Get([]byte(image))
+		if v == nil {
+			return nil
+		}
+		record = decodeAssemblyRecord(image, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// getAssemblyRecords returns all applied assembly records
+func (a *Agent) getAssemblyRecords() ([]*assemblyRecord, error) {
+	var records []*assemblyRecord
+	if err := a.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketAssemblies))
+		return b.ForEach(func(k, v []byte) error {
+			records = append(records, decodeAssemblyRecord(string(k), v))
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (a *Agent) putAssemblyRecord(record *assemblyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketAssemblies))
+		return b.Put([]byte(record.Image), data)
+	})
+}
+
+func (a *Agent) deleteAssemblyRecord(image string) error {
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketAssemblies))
+		return b.Delete([]byte(image))
+	})
+}
+
+// decodeAssemblyRecord decodes the stored record.  records written before
+// digest tracking only contain the install output and are returned without
+// a digest so the assembly is applied again on the next update.
+func decodeAssemblyRecord(image string, data []byte) *assemblyRecord {
+	var record *assemblyRecord
+	if err := json.Unmarshal(data, &record); err != nil || record == nil || record.Image == "" {
+		return &assemblyRecord{
+			Image:  image,
+			Output: data,
+		}
+	}
+	return record
+}
+
+// restoreAssemblyStatus populates the node status with the applied assemblies
+func (a *Agent) restoreAssemblyStatus() error {
+	records, err := a.getAssemblyRecords()
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		a.status.SetAssembly(&api.AssemblyStatus{
+			Image:  r.Image,
+			Digest: r.Digest.String(),
+			Status: api.AssemblyStatus_APPLIED,
+		})
+	}
+	return nil
+}
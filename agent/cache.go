@@ -0,0 +1,132 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/continuity/fs"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	assembliesDir = "assemblies"
+	// workDir contains a copy of the assembly content for each execution
+	workDir = "work"
+)
+
+// assemblyDir returns the path of the extracted content for the image digest
+func (a *Agent) assemblyDir(dgst digest.Digest) string {
+	return filepath.Join(a.config.DataDir, assembliesDir, dgst.Algorithm().String(), dgst.Hex())
+}
+
+// extractAssembly returns the directory containing the extracted content of
+// the image digest.  the image is only fetched if the content is not cached.
+// if the digest is not known the image is resolved first.
+func (a *Agent) extractAssembly(ctx context.Context, image string, dgst digest.Digest) (string, error) {
+	if dgst != "" {
+		if ok, err := a.cachedAssembly(dgst); err != nil || ok {
+			return a.assemblyDir(dgst), err
+		}
+	}
+
+	resolver := newResolver()
+	name, desc, err := resolver.Resolve(ctx, pinnedImage(image, dgst))
+	if err != nil {
+		return "", err
+	}
+	dir := a.assemblyDir(desc.Digest)
+	if ok, err := a.cachedAssembly(desc.Digest); err != nil || ok {
+		return dir, err
+	}
+
+	parent := filepath.Dir(dir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", err
+	}
+	// extract to a temporary directory so partial content is never used
+	tmpdir, err := ioutil.TempDir(parent, ".extract-")
+	if err != nil {
+		return "", err
+	}
+	if err := a.fetchImage(ctx, resolver, name, desc, tmpdir); err != nil {
+		os.RemoveAll(tmpdir)
+		return "", err
+	}
+	if err := os.Rename(tmpdir, dir); err != nil {
+		os.RemoveAll(tmpdir)
+		// the content was extracted by another apply
+		if ok, _ := a.cachedAssembly(desc.Digest); ok {
+			return dir, nil
+		}
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// executionDir returns a new directory with a copy of the extracted content of
+// the image digest.  entrypoints are run in the copy so the cached content is
+// never modified by an execution.  the caller removes the directory.
+func (a *Agent) executionDir(ctx context.Context, image string, dgst digest.Digest) (string, error) {
+	src, err := a.extractAssembly(ctx, image, dgst)
+	if err != nil {
+		return "", err
+	}
+	parent := filepath.Join(a.config.DataDir, workDir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", err
+	}
+	dir, err := ioutil.TempDir(parent, "exec-")
+	if err != nil {
+		return "", err
+	}
+	if err := fs.CopyDir(dir, src); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// cachedAssembly returns true if the content for the digest has been extracted
+func (a *Agent) cachedAssembly(dgst digest.Digest) (bool, error) {
+	if _, err := os.Stat(a.assemblyDir(dgst)); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// pruneAssemblyDirs removes the extracted content that is not referenced by
+// an applied assembly record
+func (a *Agent) pruneAssemblyDirs() error {
+	records, err := a.getAssemblyRecords()
+	if err != nil {
+		return err
+	}
+	keep := map[string]bool{}
+	for _, r := range records {
+		if r.Digest != "" {
+			keep[a.assemblyDir(r.Digest)] = true
+		}
+	}
+
+	dirs, err := filepath.Glob(filepath.Join(a.config.DataDir, assembliesDir, "*", "*"))
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if keep[dir] {
+			continue
+		}
+		logrus.WithField("path", dir).Debug("removing extracted assembly content")
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	"github.com/stellarproject/terra/manifest"
+)
+
+const (
+	defaultCanaryHealthInterval = 10 * time.Second
+)
+
+var (
+	// ErrRolloutAborted is returned when the canary nodes fail and the rollout is rolled back
+	ErrRolloutAborted = errors.New("rollout aborted")
+	// ErrNoCanaryNodes is returned when the canary does not select any nodes
+	ErrNoCanaryNodes = errors.New("no canary nodes selected")
+)
+
+// selectCanaries splits the nodes into canary nodes and the rest of the cluster.
+// nodes are selected by the selector, or the deprecated labels, if specified;
+// otherwise the percentage of nodes (at least one) is used in node order.
+func selectCanaries(nodes []*rolloutNode, canary *api.Canary) ([]*rolloutNode, []*rolloutNode, error) {
+	var canaries, rest []*rolloutNode
+	if canary.Selector != "" || len(canary.Labels) > 0 {
+		s := canary.Selector
+		if s == "" {
+			s = manifest.LabelSelector(canary.Labels)
+		}
+		selector, err := manifest.ParseSelector(s)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "canary")
+		}
+		for _, n := range nodes {
+			if selector.Matches(n.Labels) {
+				canaries = append(canaries, n)
+				continue
+			}
+			rest = append(rest, n)
+		}
+		return canaries, rest, nil
+	}
+
+	count := (len(nodes)*int(canary.Percent) + 99) / 100
+	if count < 1 {
+		count = 1
+	}
+	if count > len(nodes) {
+		count = len(nodes)
+	}
+	return nodes[:count], nodes[count:], nil
+}
+
+// canary updates the canary nodes and waits for the bake time.  if any canary
+// fails to update or is unhealthy the previous manifest list is restored on
+// the canary nodes and ErrRolloutAborted is returned.
+func (r *rollout) canary(canaries []*rolloutNode, canary *api.Canary, previous *api.ManifestList) error {
+	if len(canaries) == 0 {
+		return ErrNoCanaryNodes
+	}
+	r.event(api.RolloutEvent_CANARY_STARTED, "", fmt.Sprintf("updating %d canary nodes", len(canaries)))
+
+	err := r.run(canaries)
+	if err == nil && r.failed > 0 {
+		err = errors.Errorf("%d canary nodes failed", r.failed)
+	}
+	if err == nil {
+		err = r.bake(canaries, canary)
+	}
+	if err != nil {
+		r.event(api.RolloutEvent_ABORTED, "", err.Error())
+		r.restore(canaries, previous)
+		return ErrRolloutAborted
+	}
+
+	return nil
+}
+
+// bake checks the canary nodes every health interval until the bake time has passed
+func (r *rollout) bake(canaries []*rolloutNode, canary *api.Canary) error {
+	interval := canary.HealthInterval
+	if interval == 0 {
+		interval = defaultCanaryHealthInterval
+	}
+	deadline := time.Now().Add(canary.BakeTime)
+	for {
+		for _, node := range canaries {
+			if err := r.checkNode(node, canary.Health); err != nil {
+				r.event(api.RolloutEvent_CANARY_UNHEALTHY, node.ID, err.Error())
+				return errors.Wrapf(err, "canary %s unhealthy", node.ID)
+			}
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		r.event(api.RolloutEvent_CANARY_HEALTHY, "", fmt.Sprintf("canaries healthy; %s remaining", remaining.Round(time.Second)))
+		if remaining < interval {
+			interval = remaining
+		}
+		time.Sleep(interval)
+	}
+	r.event(api.RolloutEvent_CANARY_HEALTHY, "", "canaries healthy")
+	return nil
+}
+
+// checkNode returns an error if the node status is not OK or the health assembly fails
+func (r *rollout) checkNode(node *rolloutNode, health *api.Assembly) error {
+	if node.self {
+		if state := r.agent.status.State(); state != api.NodeStatus_OK {
+			return errors.Errorf("node status %s: %s", state, r.agent.status.Description())
+		}
+		if health == nil {
+			return nil
+		}
+		_, err := r.agent.checkHealth(context.Background(), health)
+		return err
+	}
+
+	c, err := client.NewClient(node.Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	status, err := c.Status()
+	if err != nil {
+		return err
+	}
+	if status.Status != api.NodeStatus_OK {
+		return errors.Errorf("node status %s: %s", status.Status, status.Description)
+	}
+	if health == nil {
+		return nil
+	}
+	resp, err := peerHealth(c, health)
+	if err != nil {
+		return err
+	}
+	if !resp.Healthy {
+		return errors.New(resp.Output)
+	}
+	return nil
+}
+
+// restore applies the previous manifest list to the canary nodes and removes
+// the assemblies added by the rollout
+func (r *rollout) restore(canaries []*rolloutNode, previous *api.ManifestList) {
+	if previous == nil {
+		previous = &api.ManifestList{}
+	}
+	for _, node := range canaries {
+		var err error
+		if node.self {
+			err = r.agent.applyManifestList(r.manifestList, previous, false, api.Execution_UPDATE)
+		} else {
+			err = restoreNode(node, previous, r.manifestList)
+		}
+		if err != nil {
+			r.event(api.RolloutEvent_NODE_FAILED, node.ID, fmt.Sprintf("error restoring previous manifest list: %s", err))
+			continue
+		}
+		r.event(api.RolloutEvent_NODE_OK, node.ID, "restored previous manifest list")
+	}
+}
+
+func restoreNode(node *rolloutNode, previous, current *api.ManifestList) error {
+	c, err := client.NewClient(node.Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return c.Restore(previous.Manifests, current.Manifests)
+}
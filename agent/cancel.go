@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"context"
+	"os/exec"
+	"syscall"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+)
+
+const (
+	defaultAssemblyStopTimeout = 10 * time.Second
+)
+
+var (
+	// ErrNoApplyInProgress is returned when cancelling a node that is not applying
+	ErrNoApplyInProgress = errors.New("no apply in progress")
+	// ErrApplyCancelled is returned for assemblies aborted by a cancel
+	ErrApplyCancelled = errors.New("apply cancelled")
+	// ErrAssemblyTimeout is returned when an entrypoint exceeds the assembly timeout
+	ErrAssemblyTimeout = errors.New("assembly timeout")
+)
+
+// Cancel aborts the in progress apply on the node.  running entrypoints are
+// stopped and pending assemblies are not applied.
+func (a *Agent) Cancel(ctx context.Context, req *api.CancelRequest) (*ptypes.Empty, error) {
+	if req.NodeID != "" && req.NodeID != a.config.NodeID {
+		peers, err := a.clusterAgent.Peers()
+		if err != nil {
+			return empty, err
+		}
+		for _, peer := range peers {
+			if peer.ID != req.NodeID {
+				continue
+			}
+			c, err := client.NewClient(peer.Address)
+			if err != nil {
+				return empty, err
+			}
+			defer c.Close()
+			return empty, c.Cancel(peer.ID)
+		}
+		return empty, errors.Errorf("node %s not found", req.NodeID)
+	}
+
+	a.muCancel.Lock()
+	defer a.muCancel.Unlock()
+	if a.cancelApply == nil {
+		return empty, ErrNoApplyInProgress
+	}
+	logrus.Info("cancelling apply")
+	a.cancelApply()
+	return empty, nil
+}
+
+// startApply returns a context that is cancelled by Cancel and a func that
+// must be called when the apply is complete
+func (a *Agent) startApply() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.muCancel.Lock()
+	a.cancelApply = cancel
+	a.muCancel.Unlock()
+	return ctx, func() {
+		a.muCancel.Lock()
+		a.cancelApply = nil
+		a.muCancel.Unlock()
+		cancel()
+	}
+}
+
+// assemblyTimeout returns the entrypoint timeout for the assembly
+func (a *Agent) assemblyTimeout(assembly *api.Assembly) (time.Duration, error) {
+	if assembly.Timeout == "" {
+		return a.config.AssemblyTimeout, nil
+	}
+	timeout, err := time.ParseDuration(assembly.Timeout)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid timeout for assembly %s", assembly.Image)
+	}
+	return timeout, nil
+}
+
+// runCommand starts the command in a new process group and waits for it to
+// exit.  if the context is cancelled or the timeout expires the process group
+// is sent SIGTERM and then SIGKILL after the stop timeout.
+func (a *Agent) runCommand(ctx context.Context, cmd *exec.Cmd, timeout time.Duration) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		expired = t.C
+	}
+
+	var reason error
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		reason = ErrApplyCancelled
+	case <-expired:
+		reason = errors.Wrapf(ErrAssemblyTimeout, "exceeded %s", timeout)
+	}
+
+	stopTimeout := a.config.AssemblyStopTimeout
+	if stopTimeout <= 0 {
+		stopTimeout = defaultAssemblyStopTimeout
+	}
+	pgid := -cmd.Process.Pid
+	logrus.WithField("pid", cmd.Process.Pid).Warnf("stopping %s: %s", cmd.Path, reason)
+	syscall.Kill(pgid, syscall.SIGTERM)
+	select {
+	case <-done:
+	case <-time.After(stopTimeout):
+		logrus.WithField("pid", cmd.Process.Pid).Warnf("killing %s after %s", cmd.Path, stopTimeout)
+		syscall.Kill(pgid, syscall.SIGKILL)
+		<-done
+	}
+	return reason
+}
@@ -0,0 +1,237 @@
+package agent
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// cgroupMounts are the locations of the cgroup v2 hierarchy for unified and
+// hybrid hosts
+var cgroupMounts = []string{"/sys/fs/cgroup", "/sys/fs/cgroup/unified"}
+
+// cgroup is the cgroup v2 group of a single entrypoint execution
+type cgroup struct {
+	path string
+	fd   *os.File
+}
+
+// cgroupSlice is the delegated group containing the execution groups and the
+// controllers enabled for them
+type cgroupSlice struct {
+	path        string
+	controllers map[string]bool
+}
+
+// setupCgroupSlice creates the slice under the cgroup v2 hierarchy and enables
+// the controllers delegated to it for the execution groups.  the slice is
+// created once at startup and ancestors of the slice are never modified so it
+// must be delegated to the agent.  nil is returned when the slice is not
+// configured or cgroup v2 is not available.
+func setupCgroupSlice(name string) (*cgroupSlice, error) {
+	root := cgroupRoot()
+	if name == "" || root == "" {
+		return nil, nil
+	}
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Join(path, "cgroup.controllers"))
+	if err != nil {
+		return nil, err
+	}
+	controllers := map[string]bool{}
+	for _, c := range strings.Fields(string(data)) {
+		switch c {
+		case "cpu", "memory", "pids", "io":
+		default:
+			continue
+		}
+		if err := ioutil.WriteFile(filepath.Join(path, "cgroup.subtree_control"), []byte("+"+c), 0644); err != nil {
+			return nil, errors.Wrapf(err, "error enabling cgroup controller %s", c)
+		}
+		controllers[c] = true
+	}
+	return &cgroupSlice{
+		path:        path,
+		controllers: controllers,
+	}, nil
+}
+
+// newCgroup creates a group for an execution under the agent cgroup slice
+// and applies the limits.  nil is returned when cgroups are disabled or cgroup
+// v2 is not available and no limits are set.
+func (a *Agent) newCgroup(limits *api.ResourceLimits) (*cgroup, error) {
+	if a.cgroupSlice == nil {
+		if hasLimits(limits) {
+			return nil, errors.New("resource limits require cgroup v2 and a cgroup slice")
+		}
+		return nil, nil
+	}
+	required := map[string]bool{
+		"cpu":    limits.CPU != "",
+		"memory": limits.Memory != "",
+		"pids":   limits.Pids != 0,
+		"io":     len(limits.IO) > 0,
+	}
+	for _, c := range []string{"cpu", "memory", "pids", "io"} {
+		if required[c] && !a.cgroupSlice.controllers[c] {
+			return nil, errors.Errorf("cgroup controller %s is not delegated to %s", c, a.cgroupSlice.path)
+		}
+	}
+
+	path := filepath.Join(a.cgroupSlice.path, fmt.Sprintf("run-%d", time.Now().UnixNano()))
+	if err := os.Mkdir(path, 0755); err != nil {
+		return nil, err
+	}
+	c := &cgroup{path: path}
+	if err := c.setLimits(limits); err != nil {
+		c.remove()
+		return nil, err
+	}
+	return c, nil
+}
+
+// cgroupRoot returns the mount point of the cgroup v2 hierarchy or an empty
+// string if not available
+func cgroupRoot() string {
+	for _, m := range cgroupMounts {
+		if _, err := os.Stat(filepath.Join(m, "cgroup.controllers")); err == nil {
+			return m
+		}
+	}
+	return ""
+}
+
+func (c *cgroup) setLimits(limits *api.ResourceLimits) error {
+	if limits.CPU != "" {
+		v, err := parseCPU(limits.CPU)
+		if err != nil {
+			return err
+		}
+		if err := c.write("cpu.max", v); err != nil {
+			return err
+		}
+	}
+	if limits.Memory != "" {
+		v, err := parseBytes(limits.Memory)
+		if err != nil {
+			return err
+		}
+		if err := c.write("memory.max", strconv.FormatUint(v, 10)); err != nil {
+			return err
+		}
+	}
+	if limits.Pids != 0 {
+		if err := c.write("pids.max", strconv.FormatUint(limits.Pids, 10)); err != nil {
+			return err
+		}
+	}
+	for _, v := range limits.IO {
+		if err := c.write("io.max", v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cgroup) write(file, value string) error {
+	if err := ioutil.WriteFile(filepath.Join(c.path, file), []byte(value), 0644); err != nil {
+		return errors.Wrapf(err, "error setting %s to %q", file, value)
+	}
+	return nil
+}
+
+// apply starts the command in the group
+func (c *cgroup) apply(cmd *exec.Cmd) error {
+	fd, err := os.Open(c.path)
+	if err != nil {
+		return err
+	}
+	c.fd = fd
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(fd.Fd())
+	return nil
+}
+
+// usage returns the peak usage of the group.  values not supported by the
+// kernel are zero.
+func (c *cgroup) usage() *api.ResourceUsage {
+	usage := &api.ResourceUsage{
+		MemoryPeak: c.readUint("memory.peak"),
+		PidsPeak:   c.readUint("pids.peak"),
+	}
+	if v, ok := c.readKeyed("cpu.stat")["usage_usec"]; ok {
+		usage.CPU = time.Duration(v) * time.Microsecond
+	}
+	io := c.readKeyed("io.stat")
+	usage.IOReadBytes = io["rbytes"]
+	usage.IOWriteBytes = io["wbytes"]
+	return usage
+}
+
+func (c *cgroup) readUint(file string) uint64 {
+	data, err := ioutil.ReadFile(filepath.Join(c.path, file))
+	if err != nil {
+		return 0
+	}
+	v, _ := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return v
+}
+
+// readKeyed returns the sum of each key in a flat or nested keyed file
+func (c *cgroup) readKeyed(file string) map[string]uint64 {
+	values := map[string]uint64{}
+	f, err := os.Open(filepath.Join(c.path, file))
+	if err != nil {
+		return values
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		// flat keyed files are "key value"
+		if len(fields) == 2 && !strings.Contains(fields[1], "=") {
+			v, _ := strconv.ParseUint(fields[1], 10, 64)
+			values[fields[0]] += v
+			continue
+		}
+		// nested keyed files are "device key=value ..."
+		for _, field := range fields {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			v, _ := strconv.ParseUint(kv[1], 10, 64)
+			values[kv[0]] += v
+		}
+	}
+	return values
+}
+
+// remove deletes the group.  processes left running by the entrypoint keep
+// the group from being removed.
+func (c *cgroup) remove() {
+	if c.fd != nil {
+		c.fd.Close()
+	}
+	if err := os.Remove(c.path); err != nil {
+		logrus.WithError(err).Warnf("error removing cgroup %s", c.path)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build !linux
+// +build !linux
+
+package agent
+
+import (
+	"os/exec"
+
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// cgroup is not supported outside linux
+type cgroup struct{}
+
+type cgroupSlice struct{}
+
+func setupCgroupSlice(name string) (*cgroupSlice, error) {
+	return nil, nil
+}
+
+// newCgroup returns an error if resource limits are set as cgroups are only
+// supported on linux
+func (a *Agent) newCgroup(limits *api.ResourceLimits) (*cgroup, error) {
+	if hasLimits(limits) {
+		return nil, errors.New("resource limits are only supported on linux")
+	}
+	return nil, nil
+}
+
+func (c *cgroup) apply(cmd *exec.Cmd) error {
+	return nil
+}
+
+func (c *cgroup) usage() *api.ResourceUsage {
+	return nil
+}
+
+func (c *cgroup) remove() {}
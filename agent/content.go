@@ -0,0 +1,386 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/archive"
+	"github.com/containerd/containerd/archive/compression"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	contentDir              = "content"
+	defaultFetchConcurrency = 3
+	// staleDownload is the age after which a partial download is no longer
+	// resumed and is removed by garbage collection
+	staleDownload = 24 * time.Hour
+)
+
+// imageRecord is the last fetched descriptor of an image stored in bucketImages
+type imageRecord struct {
+	Name       string             `json:"name"`
+	Descriptor ocispec.Descriptor `json:"descriptor"`
+	Fetched    time.Time          `json:"fetched"`
+}
+
+// blobRef is a blob reachable from the recorded images
+type blobRef struct {
+	mediaType  string
+	images     []string
+	referenced bool
+}
+
+// Content returns the blobs and partial downloads in the node content store
+func (a *Agent) Content(ctx context.Context, req *api.ContentRequest) (*api.ContentResponse, error) {
+	a.muContent.RLock()
+	defer a.muContent.RUnlock()
+
+	refs, err := a.contentRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blobs := []*api.Blob{}
+	if err := a.contentStore.Walk(ctx, func(info content.Info) error {
+		blobs = append(blobs, newBlob(info, refs[info.Digest]))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	statuses, err := a.contentStore.ListStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	downloads := []*api.Download{}
+	for _, s := range statuses {
+		downloads = append(downloads, &api.Download{
+			Ref:     s.Ref,
+			Offset:  s.Offset,
+			Total:   s.Total,
+			Updated: s.UpdatedAt,
+		})
+	}
+	sort.Slice(downloads, func(i, j int) bool {
+		return downloads[i].Ref < downloads[j].Ref
+	})
+	return &api.ContentResponse{
+		Blobs:     blobs,
+		Downloads: downloads,
+	}, nil
+}
+
+// PruneContent removes the blobs not referenced by a current or recent revision
+func (a *Agent) PruneContent(ctx context.Context, req *api.PruneContentRequest) (*api.PruneContentResponse, error) {
+	return a.pruneContent(ctx, req.DryRun)
+}
+
+func newBlob(info content.Info, ref *blobRef) *api.Blob {
+	b := &api.Blob{
+		Digest:  info.Digest.String(),
+		Size_:   info.Size,
+		Created: info.CreatedAt,
+	}
+	if ref != nil {
+		b.MediaType = ref.mediaType
+		b.Images = ref.images
+		b.Referenced = ref.referenced
+	}
+	return b
+}
+
+// pruneContent removes the blobs that are not reachable from an image in the
+// stored revisions or an applied assembly, the records of those images and the
+// partial downloads that have not been resumed within staleDownload
+func (a *Agent) pruneContent(ctx context.Context, dryRun bool) (*api.PruneContentResponse, error) {
+	a.muContent.Lock()
+	defer a.muContent.Unlock()
+
+	refs, err := a.contentRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &api.PruneContentResponse{
+		Removed: []*api.Blob{},
+	}
+	if err := a.contentStore.Walk(ctx, func(info content.Info) error {
+		if ref, ok := refs[info.Digest]; !ok || !ref.referenced {
+			resp.Removed = append(resp.Removed, newBlob(info, ref))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	statuses, err := a.contentStore.ListStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statuses {
+		if time.Since(s.UpdatedAt) > staleDownload {
+			resp.Aborted = append(resp.Aborted, s.Ref)
+		}
+	}
+	if dryRun {
+		return resp, nil
+	}
+
+	for _, b := range resp.Removed {
+		logrus.WithField("digest", b.Digest).Debug("removing content")
+		if err := a.contentStore.Delete(ctx, digest.Digest(b.Digest)); err != nil && !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	for _, ref := range resp.Aborted {
+		logrus.WithField("ref", ref).Debug("removing stale download")
+		if err := a.contentStore.Abort(ctx, ref); err != nil && !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	if err := a.pruneImageRecords(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// contentRefs returns the blobs reachable from the recorded images.  blobs of
+// images in the stored revisions or applied assemblies are referenced.
+func (a *Agent) contentRefs(ctx context.Context) (map[digest.Digest]*blobRef, error) {
+	records, err := a.getImageRecords()
+	if err != nil {
+		return nil, err
+	}
+	names, digests, err := a.contentRoots()
+	if err != nil {
+		return nil, err
+	}
+	refs := map[digest.Digest]*blobRef{}
+	for _, r := range records {
+		referenced := names[r.Name] || digests[r.Descriptor.Digest]
+		if err := images.Walk(ctx, images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			ref, ok := refs[desc.Digest]
+			if !ok {
+				ref = &blobRef{mediaType: desc.MediaType}
+				refs[desc.Digest] = ref
+			}
+			if n := len(ref.images); n == 0 || ref.images[n-1] != r.Name {
+				ref.images = append(ref.images, r.Name)
+			}
+			ref.referenced = ref.referenced || referenced
+			children, err := images.Children(ctx, a.contentStore, desc)
+			if err != nil && errdefs.IsNotFound(err) {
+				// the blob was not fetched for this platform
+				return nil, nil
+			}
+			return children, err
+		}), r.Descriptor); err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+// contentRoots returns the image names in the stored revisions and applied
+// assemblies and the digests of the applied assemblies
+func (a *Agent) contentRoots() (map[string]bool, map[digest.Digest]bool, error) {
+	names := map[string]bool{}
+	digests := map[digest.Digest]bool{}
+	revisions, err := a.getRevisions()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ml := range revisions {
+		for _, m := range ml.Manifests {
+			for _, assembly := range m.Assemblies {
+				names[imageName(assembly.Image)] = true
+			}
+		}
+	}
+	records, err := a.getAssemblyRecords()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range records {
+		names[imageName(r.Image)] = true
+		if r.Digest != "" {
+			digests[r.Digest] = true
+		}
+	}
+	return names, digests, nil
+}
+
+// imageName returns the image reference without a pinned digest
+func imageName(image string) string {
+	return strings.SplitN(image, "@", 2)[0]
+}
+
+func (a *Agent) getImageRecords() ([]*imageRecord, error) {
+	var records []*imageRecord
+	if err := a.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketImages))
+		return b.ForEach(func(k, v []byte) error {
+			var r *imageRecord
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			records = append(records, r)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (a *Agent) putImageRecord(record *imageRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketImages))
+		return b.Put([]byte(record.Name), data)
+	})
+}
+
+// pruneImageRecords removes the records of images that are no longer referenced
+func (a *Agent) pruneImageRecords() error {
+	names, digests, err := a.contentRoots()
+	if err != nil {
+		return err
+	}
+	records, err := a.getImageRecords()
+	if err != nil {
+		return err
+	}
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketImages))
+		for _, r := range records {
+			if names[r.Name] || digests[r.Descriptor.Digest] {
+				continue
+			}
+			if err := b.Delete([]byte(r.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// fetchImage fetches the resolved image into the content store and extracts
+// the layers to dest.  blobs already in the store are not fetched again and
+// interrupted downloads are resumed.  blobs are fetched from the peers that
+// have the image before the registry.
+func (a *Agent) fetchImage(ctx context.Context, resolver remotes.Resolver, name string, desc ocispec.Descriptor, dest string) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	a.muContent.RLock()
+	defer a.muContent.RUnlock()
+
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return err
+	}
+	concurrency := a.config.FetchConcurrency
+	if concurrency < 1 {
+		concurrency = defaultFetchConcurrency
+	}
+	h := images.Handlers(
+		limitHandler(a.fetchHandler(fetcher, a.contentPeers(desc.Digest)), concurrency),
+		images.FilterPlatforms(images.ChildrenHandler(a.contentStore), platforms.Default()),
+	)
+	if err := images.Dispatch(ctx, h, desc); err != nil {
+		return err
+	}
+	if err := a.putImageRecord(&imageRecord{
+		Name:       imageName(name),
+		Descriptor: desc,
+		Fetched:    time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	layers, err := imageLayers(ctx, a.contentStore, desc)
+	if err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		if err := applyLayer(ctx, a.contentStore, layer, dest); err != nil {
+			return errors.Wrapf(err, "error applying layer %s", layer.Digest)
+		}
+	}
+	return nil
+}
+
+// limitHandler runs at most n calls of the handler at once
+func limitHandler(h images.Handler, n int) images.HandlerFunc {
+	sem := make(chan struct{}, n)
+	return func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-sem }()
+		return h.Handle(ctx, desc)
+	}
+}
+
+// imageLayers returns the layers of the image in apply order.  for an index
+// the manifest for the node platform is used.
+func imageLayers(ctx context.Context, provider content.Provider, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	p, err := content.ReadBlob(ctx, provider, desc)
+	if err != nil {
+		return nil, err
+	}
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+		var index ocispec.Index
+		if err := json.Unmarshal(p, &index); err != nil {
+			return nil, err
+		}
+		matcher := platforms.Default()
+		for _, m := range index.Manifests {
+			if m.Platform == nil || matcher.Match(*m.Platform) {
+				return imageLayers(ctx, provider, m)
+			}
+		}
+		return nil, errors.Errorf("no manifest for platform %s in %s", platforms.DefaultString(), desc.Digest)
+	case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(p, &manifest); err != nil {
+			return nil, err
+		}
+		return manifest.Layers, nil
+	}
+	return nil, errors.Errorf("unsupported media type %s for %s", desc.MediaType, desc.Digest)
+}
+
+// applyLayer extracts the layer blob from the store to dest
+func applyLayer(ctx context.Context, provider content.Provider, desc ocispec.Descriptor, dest string) error {
+	ra, err := provider.ReaderAt(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	r, err := compression.DecompressStream(content.NewReader(ra))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	_, err = archive.Apply(ctx, dest, r)
+	return err
+}
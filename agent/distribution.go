@@ -0,0 +1,285 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+)
+
+const (
+	contentChunkSize = 256 * 1024
+	// peerContentTimeout limits asking a peer for the images it has fetched
+	peerContentTimeout     = 2 * time.Second
+	peerContentConcurrency = 16
+	// maxPeerAttempts is the number of peers tried for a blob before the registry
+	maxPeerAttempts = 3
+)
+
+// ContentImages returns the requested digests of the images fetched completely
+// into the content store.  the blobs of these images are served to peers.
+func (a *Agent) ContentImages(ctx context.Context, req *api.ContentImagesRequest) (*api.ContentImagesResponse, error) {
+	fetched, err := a.fetchedImages()
+	if err != nil {
+		return nil, err
+	}
+	digests := []string{}
+	for _, d := range req.Digests {
+		if !fetched[digest.Digest(d)] {
+			continue
+		}
+		if _, err := a.contentStore.Info(ctx, digest.Digest(d)); err != nil {
+			continue
+		}
+		digests = append(digests, d)
+	}
+	return &api.ContentImagesResponse{
+		Digests: digests,
+	}, nil
+}
+
+// ReadContent streams a blob from the content store starting at the offset
+func (a *Agent) ReadContent(req *api.ReadContentRequest, stream api.Terra_ReadContentServer) error {
+	dgst, err := digest.Parse(req.Digest)
+	if err != nil {
+		return err
+	}
+	// the open blob can still be read if it is removed by garbage collection
+	ra, err := a.contentStore.ReaderAt(stream.Context(), ocispec.Descriptor{Digest: dgst})
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	if req.Offset < 0 || req.Offset > ra.Size() {
+		return errors.Errorf("invalid offset %d for %s of size %d", req.Offset, dgst, ra.Size())
+	}
+
+	r := io.NewSectionReader(ra, req.Offset, ra.Size()-req.Offset)
+	buf := make([]byte, contentChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&api.ContentChunk{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// fetchedImages returns the digests of the recorded images
+func (a *Agent) fetchedImages() (map[digest.Digest]bool, error) {
+	records, err := a.getImageRecords()
+	if err != nil {
+		return nil, err
+	}
+	fetched := map[digest.Digest]bool{}
+	for _, r := range records {
+		fetched[r.Descriptor.Digest] = true
+	}
+	return fetched, nil
+}
+
+// contentPeers returns the addresses of the peers that have fetched the image
+// in random order so downloads are spread across the peers.  no peers are
+// returned if the image has already been fetched on this node.
+func (a *Agent) contentPeers(dgst digest.Digest) []string {
+	if a.config.DisablePeerFetch {
+		return nil
+	}
+	if fetched, err := a.fetchedImages(); err != nil || fetched[dgst] {
+		return nil
+	}
+	peers, err := a.clusterAgent.Peers()
+	if err != nil {
+		logrus.WithError(err).Warn("error getting peers for content")
+		return nil
+	}
+
+	var (
+		mu        = &sync.Mutex{}
+		wg        = &sync.WaitGroup{}
+		sem       = make(chan struct{}, peerContentConcurrency)
+		addresses = []string{}
+	)
+	for _, peer := range peers {
+		if peer.ID == a.config.NodeID {
+			continue
+		}
+		wg.Add(1)
+		go func(id, address string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ok, err := peerHasImage(address, dgst)
+			if err != nil {
+				logrus.WithError(err).Debugf("error checking content of peer %s", id)
+				return
+			}
+			if ok {
+				mu.Lock()
+				addresses = append(addresses, address)
+				mu.Unlock()
+			}
+		}(peer.ID, peer.Address)
+	}
+	wg.Wait()
+
+	rand.Shuffle(len(addresses), func(i, j int) {
+		addresses[i], addresses[j] = addresses[j], addresses[i]
+	})
+	return addresses
+}
+
+// peerHasImage returns true if the peer has fetched the image completely
+func peerHasImage(address string, dgst digest.Digest) (bool, error) {
+	c, err := client.NewClient(address)
+	if err != nil {
+		return false, err
+	}
+	defer c.Close()
+	// closing the client ends the request for an unresponsive peer
+	t := time.AfterFunc(peerContentTimeout, func() {
+		c.Close()
+	})
+	defer t.Stop()
+
+	digests, err := c.ContentImages([]string{dgst.String()})
+	if err != nil {
+		return false, err
+	}
+	return len(digests) > 0, nil
+}
+
+// fetchHandler fetches each blob into the content store from the peers and
+// uses the registry fetcher if no peer can provide the blob
+func (a *Agent) fetchHandler(fetcher remotes.Fetcher, peers []string) images.HandlerFunc {
+	registry := remotes.FetchHandler(a.contentStore, fetcher)
+	return func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		for i, address := range peers {
+			if i == maxPeerAttempts {
+				break
+			}
+			err := a.fetchPeerBlob(ctx, address, desc)
+			if err == nil {
+				return nil, nil
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			logrus.WithError(err).WithField("digest", desc.Digest).Warnf("error fetching content from peer %s", address)
+		}
+		return registry(ctx, desc)
+	}
+}
+
+// fetchPeerBlob fetches the blob from the peer into the content store.  the
+// content is verified against the descriptor digest and size on commit.
+func (a *Agent) fetchPeerBlob(ctx context.Context, address string, desc ocispec.Descriptor) error {
+	ref := "peer-" + desc.Digest.String()
+	cw, err := content.OpenWriter(ctx, a.contentStore, content.WithRef(ref), content.WithDescriptor(desc))
+	if err != nil {
+		if errdefs.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+
+	c, err := client.NewClient(address)
+	if err != nil {
+		cw.Close()
+		return err
+	}
+	defer c.Close()
+	// closing the client ends the peer stream when the fetch is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Close()
+		case <-done:
+		}
+	}()
+
+	err = content.Copy(ctx, cw, &peerReader{
+		client: c,
+		digest: desc.Digest.String(),
+		size:   desc.Size,
+	}, desc.Size, desc.Digest)
+	cw.Close()
+	if err != nil {
+		// content not matching the descriptor is removed instead of resumed
+		if errdefs.IsFailedPrecondition(err) {
+			if err := a.contentStore.Abort(ctx, ref); err != nil && !errdefs.IsNotFound(err) {
+				logrus.WithError(err).Warnf("error removing download %s", ref)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// peerReader reads a blob streamed from a peer up to the blob size.  the
+// download is resumed by seeking to the offset before the first read.
+type peerReader struct {
+	client *client.Client
+	digest string
+	size   int64
+	offset int64
+	stream api.Terra_ReadContentClient
+	buf    []byte
+}
+
+func (r *peerReader) Seek(offset int64, whence int) (int64, error) {
+	if r.stream != nil || whence != io.SeekStart {
+		return 0, errors.New("peer content can only be seeked from the start before reading")
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *peerReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.stream == nil {
+		stream, err := r.client.ReadContent(r.digest, r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.stream = stream
+	}
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+	if remaining := r.size - r.offset; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.offset += int64(n)
+	return n, nil
+}
@@ -0,0 +1,210 @@
+package agent
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	defaultExecutionHistory = 1000
+	// maxExecutionOutput is the number of trailing output bytes kept per execution
+	maxExecutionOutput = 64 * 1024
+)
+
+// Executions returns the recorded assembly executions for this node and,
+// unless a node is specified, for every peer in the cluster
+func (a *Agent) Executions(ctx context.Context, req *api.ExecutionsRequest) (*api.ExecutionsResponse, error) {
+	self := a.clusterAgent.Self()
+	executions := []*api.Execution{}
+	if req.NodeID == "" || req.NodeID == self.ID {
+		local, err := a.getExecutions(req.Image, int(req.Limit))
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, local...)
+		if req.NodeID != "" {
+			return &api.ExecutionsResponse{
+				Executions: executions,
+			}, nil
+		}
+	}
+
+	peers, err := a.clusterAgent.Peers()
+	if err != nil {
+		return nil, err
+	}
+	found := req.NodeID == ""
+	for _, peer := range peers {
+		if req.NodeID != "" && peer.ID != req.NodeID {
+			continue
+		}
+		found = true
+		remote, err := peerExecutions(peer.Address, &api.ExecutionsRequest{
+			NodeID: peer.ID,
+			Image:  req.Image,
+			Limit:  req.Limit,
+		})
+		if err != nil {
+			if req.NodeID != "" {
+				return nil, err
+			}
+			logrus.WithError(err).Warnf("error getting executions from peer %s", peer.ID)
+			continue
+		}
+		executions = append(executions, remote...)
+	}
+	if !found {
+		return nil, errors.Errorf("node %s not found", req.NodeID)
+	}
+
+	return &api.ExecutionsResponse{
+		Executions: executions,
+	}, nil
+}
+
+func peerExecutions(address string, req *api.ExecutionsRequest) ([]*api.Execution, error) {
+	c, err := client.NewClient(address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	return c.Executions(req)
+}
+
+func executionKey(id uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, id)
+	return k
+}
+
+// applyTrigger returns the trigger for an apply requested by a user or peer
+func applyTrigger(force bool) api.Execution_Trigger {
+	if force {
+		return api.Execution_FORCE
+	}
+	return api.Execution_UPDATE
+}
+
+// newExecution returns an execution of the assembly entrypoint started now
+func (a *Agent) newExecution(assembly *api.Assembly, entrypoint string, trigger api.Execution_Trigger) *api.Execution {
+	return &api.Execution{
+		NodeID:         a.config.NodeID,
+		Image:          assembly.Image,
+		Entrypoint:     entrypoint,
+		ParametersHash: a.assemblyParametersHash(assembly),
+		Trigger:        trigger,
+		Started:        time.Now(),
+		ExitCode:       -1,
+	}
+}
+
+// finishExecution records the result of the execution.  errors storing the
+// record are logged so they do not fail the assembly.
+func (a *Agent) finishExecution(e *api.Execution, output []byte, err error) {
+	e.Finished = time.Now()
+	e.Duration = e.Finished.Sub(e.Started)
+	if len(output) > maxExecutionOutput {
+		output = output[len(output)-maxExecutionOutput:]
+	}
+	e.Output = string(output)
+	e.Result = api.Execution_SUCCESS
+	if err == nil {
+		e.ExitCode = 0
+	} else {
+		e.Result = api.Execution_FAILURE
+		e.Error = err.Error()
+		switch cause := errors.Cause(err).(type) {
+		case *exec.ExitError:
+			e.ExitCode = int32(cause.ExitCode())
+			e.Error = cause.Error()
+		default:
+			switch cause {
+			case ErrApplyCancelled, context.Canceled:
+				e.Result = api.Execution_CANCELLED
+			case ErrAssemblyTimeout:
+				e.Result = api.Execution_TIMEOUT
+			}
+		}
+	}
+
+	if err := a.putExecution(e); err != nil {
+		logrus.WithError(err).Errorf("error recording execution of %s", e.Image)
+	}
+}
+
+// putExecution stores the execution and removes the oldest executions beyond
+// the configured retention
+func (a *Agent) putExecution(e *api.Execution) error {
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketExecutions))
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		e.ID = id
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(executionKey(id), data); err != nil {
+			return err
+		}
+
+		retention := a.config.ExecutionHistory
+		if retention < 1 {
+			retention = defaultExecutionHistory
+		}
+		if id <= uint64(retention) {
+			return nil
+		}
+		// ids are sequential so everything at or below the oldest kept id is removed
+		oldest := id - uint64(retention)
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil && binary.BigEndian.Uint64(k) <= oldest; k, _ = c.Next() {
+			keys = append(keys, append([]byte{}, k...))
+		}
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// getExecutions returns the most recent executions first.  if image is
+// specified only executions of the image are returned.
+func (a *Agent) getExecutions(image string, limit int) ([]*api.Execution, error) {
+	executions := []*api.Execution{}
+	if err := a.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketExecutions)).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if limit > 0 && len(executions) >= limit {
+				break
+			}
+			var e *api.Execution
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			if image != "" && e.Image != image {
+				continue
+			}
+			executions = append(executions, e)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
@@ -0,0 +1,248 @@
+package agent
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stellarproject/terra/client"
+	"github.com/stellarproject/terra/manifest"
+)
+
+const (
+	// peerLabelsTimeout limits asking a peer for its labels
+	peerLabelsTimeout     = 2 * time.Second
+	peerLabelsConcurrency = 16
+)
+
+// collectFacts returns the facts of the host.  facts that cannot be read are
+// omitted.
+func collectFacts() map[string]string {
+	facts := map[string]string{
+		"os":   runtime.GOOS,
+		"arch": runtime.GOARCH,
+		"cpus": strconv.Itoa(runtime.NumCPU()),
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		facts["hostname"] = hostname
+	}
+	if v := readFact("/proc/sys/kernel/osrelease"); v != "" {
+		facts["kernel"] = v
+	}
+	if v := readFact("/proc/sys/kernel/random/boot_id"); v != "" {
+		facts["boot-id"] = v
+	}
+	if v := osRelease(); v != "" {
+		facts["os-release"] = v
+	}
+	if v := memTotal(); v != 0 {
+		facts["memory"] = strconv.FormatUint(v, 10)
+	}
+	if v := disks(); len(v) > 0 {
+		facts["disks"] = strings.Join(v, ",")
+	}
+	if v := interfaces(); len(v) > 0 {
+		facts["interfaces"] = strings.Join(v, ",")
+	}
+	return facts
+}
+
+func readFact(path string) string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// osRelease returns the distribution id and version from os-release (e.g. debian-12)
+func osRelease() string {
+	f, err := os.Open("/etc/os-release")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		kv := strings.SplitN(s.Text(), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		values[kv[0]] = strings.Trim(kv[1], `"'`)
+	}
+	if values["ID"] == "" || values["VERSION_ID"] == "" {
+		return values["ID"]
+	}
+	return values["ID"] + "-" + values["VERSION_ID"]
+}
+
+// memTotal returns the total memory in bytes
+func memTotal() uint64 {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kb, _ := strconv.ParseUint(fields[1], 10, 64)
+		return kb * 1024
+	}
+	return 0
+}
+
+// disks returns the block devices excluding virtual devices
+func disks() []string {
+	entries, err := ioutil.ReadDir("/sys/block")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		// virtual devices have no backing device
+		if _, err := os.Stat(filepath.Join("/sys/block", e.Name(), "device")); err != nil {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// interfaces returns the network interfaces that are up excluding loopback
+func interfaces() []string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, i := range ifaces {
+		if i.Flags&net.FlagUp == 0 || i.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		names = append(names, i.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// updateFacts collects the node facts
+func (a *Agent) updateFacts() {
+	facts := collectFacts()
+	a.muFacts.Lock()
+	a.nodeFacts = facts
+	a.muFacts.Unlock()
+}
+
+// refreshFacts collects the node facts every facts interval
+func (a *Agent) refreshFacts() {
+	t := time.NewTicker(a.config.FactsInterval)
+	for range t.C {
+		a.updateFacts()
+	}
+}
+
+// facts returns the collected facts of the node
+func (a *Agent) facts() map[string]string {
+	a.muFacts.Lock()
+	defer a.muFacts.Unlock()
+	facts := make(map[string]string, len(a.nodeFacts))
+	for k, v := range a.nodeFacts {
+		facts[k] = v
+	}
+	return facts
+}
+
+// labels returns the configured node labels and the facts as labels under
+// manifest.FactLabelPrefix.  the fact labels are served by the Status RPC
+// instead of the cluster agent as the gossiped node metadata is limited in size.
+func (a *Agent) labels() map[string]string {
+	labels := map[string]string{}
+	for k, v := range a.config.Labels {
+		labels[k] = v
+	}
+	for k, v := range a.facts() {
+		labels[manifest.FactLabelPrefix+k] = v
+	}
+	return labels
+}
+
+// peerNodes returns the cluster peers with the labels reported by each peer.
+// peers are queried concurrently and the gossiped labels are used for peers
+// that cannot be reached or do not respond within peerLabelsTimeout.
+func (a *Agent) peerNodes() ([]manifest.Node, error) {
+	peers, err := a.clusterAgent.Peers()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		wg    = &sync.WaitGroup{}
+		sem   = make(chan struct{}, peerLabelsConcurrency)
+		nodes = make([]manifest.Node, len(peers))
+	)
+	for i, peer := range peers {
+		nodes[i] = manifest.Node{
+			ID:      peer.ID,
+			Address: peer.Address,
+			Labels:  peer.Labels,
+		}
+		wg.Add(1)
+		go func(node *manifest.Node) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			labels, err := peerLabels(node.Address)
+			if err != nil {
+				logrus.WithError(err).Warnf("error getting labels of peer %s", node.ID)
+				return
+			}
+			if labels != nil {
+				node.Labels = labels
+			}
+		}(&nodes[i])
+	}
+	wg.Wait()
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes, nil
+}
+
+// peerLabels returns the labels reported by the peer or nil if the peer does
+// not report labels
+func peerLabels(address string) (map[string]string, error) {
+	c, err := client.NewClient(address)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	// closing the client ends the request for an unresponsive peer
+	t := time.AfterFunc(peerLabelsTimeout, func() {
+		c.Close()
+	})
+	defer t.Stop()
+
+	status, err := c.Status()
+	if err != nil {
+		return nil, err
+	}
+	return status.Labels, nil
+}
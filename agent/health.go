@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"time"
+
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+const (
+	// healthTimeout limits fetching and running the health executable
+	healthTimeout = 5 * time.Minute
+)
+
+// Health runs the health executable from the assembly on the node
+func (a *Agent) Health(ctx context.Context, req *api.HealthRequest) (*api.HealthResponse, error) {
+	if req.Assembly == nil || req.Assembly.Image == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "health assembly image required")
+	}
+	output, err := a.checkHealth(ctx, req.Assembly)
+	if err != nil {
+		return &api.HealthResponse{
+			Healthy: false,
+			Output:  err.Error(),
+		}, nil
+	}
+	return &api.HealthResponse{
+		Healthy: true,
+		Output:  string(output),
+	}, nil
+}
+
+// checkHealth fetches the assembly and runs ./health; a non-zero exit is
+// unhealthy.  the check fails if it does not complete within healthTimeout.
+func (a *Agent) checkHealth(ctx context.Context, assembly *api.Assembly) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	tmpdir, err := ioutil.TempDir("", "terra-health-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	resolver := newResolver()
+	name, desc, err := resolver.Resolve(ctx, assembly.Image)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.fetchImage(ctx, resolver, name, desc, tmpdir); err != nil {
+		return nil, err
+	}
+
+	return a.execAssembly(ctx, assembly, tmpdir, "./health", nil)
+}
+
+// peerHealth runs the health executable on the peer.  the client is closed if
+// the peer does not respond within healthTimeout.
+func peerHealth(c *client.Client, assembly *api.Assembly) (*api.HealthResponse, error) {
+	t := time.AfterFunc(healthTimeout, func() {
+		c.Close()
+	})
+	defer t.Stop()
+	return c.Health(assembly)
+}
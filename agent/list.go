@@ -3,7 +3,7 @@ package agent
 import (
 	"context"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
 )
 
 func (a *Agent) List(ctx context.Context, req *api.ListRequest) (*api.ListResponse, error) {
@@ -0,0 +1,270 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+)
+
+const (
+	// maxLogLineSize is the size after which a partial line is published so
+	// output without newlines is streamed and not buffered without limit
+	maxLogLineSize = 64 * 1024
+	// maxLogExecutions is the number of recent executions kept in memory
+	maxLogExecutions = 32
+	// maxLogLines is the number of lines kept per execution
+	maxLogLines         = 1000
+	logSubscriberBuffer = 256
+)
+
+// Logs streams the output of recent and running assembly executions.  if
+// cluster is specified the output of every peer is streamed as well.
+func (a *Agent) Logs(req *api.LogsRequest, stream api.Terra_LogsServer) error {
+	ctx, cancelPeers := context.WithCancel(stream.Context())
+	mu := &sync.Mutex{}
+	send := func(entry *api.LogEntry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(entry)
+	}
+
+	wg := &sync.WaitGroup{}
+	// the peer streams are stopped and waited for on every return so no peer
+	// sends to the stream after the handler has returned
+	defer func() {
+		cancelPeers()
+		wg.Wait()
+	}()
+	if req.Cluster {
+		peers, err := a.clusterAgent.Peers()
+		if err != nil {
+			return err
+		}
+		for _, peer := range peers {
+			wg.Add(1)
+			go func(id, address string) {
+				defer wg.Done()
+				if err := streamPeerLogs(address, req, send, ctx.Done()); err != nil {
+					logrus.WithError(err).Warnf("error streaming logs from peer %s", id)
+				}
+			}(peer.ID, peer.Address)
+		}
+	}
+
+	// subscribe before sending the backlog so no output is missed
+	ch, cancel := a.logs.subscribe()
+	defer cancel()
+
+	for _, entry := range a.logs.backlog(req.Image, int(req.Tail)) {
+		if err := send(entry); err != nil {
+			return err
+		}
+	}
+
+	if req.Follow {
+		for {
+			select {
+			case entry := <-ch:
+				if req.Image != "" && entry.Image != req.Image {
+					continue
+				}
+				if err := send(entry); err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				wg.Wait()
+				return nil
+			}
+		}
+	}
+
+	wg.Wait()
+	return nil
+}
+
+func streamPeerLogs(address string, req *api.LogsRequest, send func(*api.LogEntry) error, done <-chan struct{}) error {
+	c, err := client.NewClient(address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	stream, err := c.Logs(&api.LogsRequest{
+		Follow: req.Follow,
+		Image:  req.Image,
+		Tail:   req.Tail,
+	})
+	if err != nil {
+		return err
+	}
+	// closing the client ends the peer stream when the caller goes away
+	go func() {
+		<-done
+		c.Close()
+	}()
+	for {
+		entry, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			select {
+			case <-done:
+				return nil
+			default:
+			}
+			return err
+		}
+		if err := send(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// execution is the output of a single assembly execution
+type execution struct {
+	id         uint64
+	image      string
+	entrypoint string
+	entries    []*api.LogEntry
+}
+
+// logHub keeps the output of recent executions and publishes new output to subscribers
+type logHub struct {
+	mu          *sync.Mutex
+	nodeID      string
+	nextID      uint64
+	executions  []*execution
+	subscribers map[chan *api.LogEntry]struct{}
+}
+
+func newLogHub(nodeID string) *logHub {
+	return &logHub{
+		mu:          &sync.Mutex{},
+		nodeID:      nodeID,
+		subscribers: map[chan *api.LogEntry]struct{}{},
+	}
+}
+
+// start registers a new execution and drops the oldest beyond the limit
+func (h *logHub) start(image, entrypoint string) *execution {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nextID++
+	e := &execution{
+		id:         h.nextID,
+		image:      image,
+		entrypoint: entrypoint,
+	}
+	h.executions = append(h.executions, e)
+	if len(h.executions) > maxLogExecutions {
+		h.executions = h.executions[len(h.executions)-maxLogExecutions:]
+	}
+	return e
+}
+
+// publish records the line for the execution and sends it to subscribers
+func (h *logHub) publish(e *execution, stream, line string) {
+	entry := &api.LogEntry{
+		NodeID:     h.nodeID,
+		Image:      e.image,
+		Entrypoint: e.entrypoint,
+		Stream:     stream,
+		Line:       line,
+		Timestamp:  time.Now(),
+		Execution:  e.id,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e.entries = append(e.entries, entry)
+	if len(e.entries) > maxLogLines {
+		e.entries = e.entries[len(e.entries)-maxLogLines:]
+	}
+	for ch := range h.subscribers {
+		select {
+		case ch <- entry:
+		default:
+			// drop output for slow subscribers instead of blocking the execution
+		}
+	}
+}
+
+// subscribe returns a channel receiving new output and a func to unsubscribe
+func (h *logHub) subscribe() (chan *api.LogEntry, func()) {
+	ch := make(chan *api.LogEntry, logSubscriberBuffer)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subscribers, ch)
+		h.mu.Unlock()
+	}
+}
+
+// backlog returns the recorded output of recent executions; tail limits the lines per execution
+func (h *logHub) backlog(image string, tail int) []*api.LogEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries := []*api.LogEntry{}
+	for _, e := range h.executions {
+		if image != "" && e.image != image {
+			continue
+		}
+		lines := e.entries
+		if tail > 0 && len(lines) > tail {
+			lines = lines[len(lines)-tail:]
+		}
+		entries = append(entries, lines...)
+	}
+	return entries
+}
+
+// writer returns a writer publishing each line written for the stream after
+// passing it through redact
+func (h *logHub) writer(e *execution, stream string, redact func(string) string) *lineWriter {
+	return &lineWriter{
+		fn: func(line string) {
+			h.publish(e, stream, redact(line))
+		},
+	}
+}
+
+// lineWriter calls fn for each complete line written.  partial lines longer
+// than maxLogLineSize are published in parts.
+type lineWriter struct {
+	buf bytes.Buffer
+	fn  func(string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := w.buf.Next(i + 1)
+		w.fn(string(bytes.TrimRight(line, "\r\n")))
+	}
+	for w.buf.Len() >= maxLogLineSize {
+		w.fn(string(w.buf.Next(maxLogLineSize)))
+	}
+	return len(p), nil
+}
+
+// Close publishes any remaining partial line
+func (w *lineWriter) Close() error {
+	if w.buf.Len() > 0 {
+		w.fn(w.buf.String())
+		w.buf.Reset()
+	}
+	return nil
+}
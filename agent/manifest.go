@@ -5,113 +5,290 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
-	"github.com/containerd/containerd/archive"
-	"github.com/containerd/containerd/archive/compression"
-	"github.com/containerd/containerd/content"
-	"github.com/containerd/containerd/content/local"
-	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
-	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	api "github.com/stellarproject/nebula/terra/v1"
-	"github.com/stellarproject/terra/client"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/manifest"
 	bolt "go.etcd.io/bbolt"
 )
 
-func (a *Agent) applyManifestList(ml *api.ManifestList, force bool) error {
+// applyManifestList removes the assemblies no longer in the manifest list and
+// applies the current assemblies.  trigger is recorded with each execution.
+func (a *Agent) applyManifestList(prev, ml *api.ManifestList, force bool, trigger api.Execution_Trigger) error {
+	a.muApply.Lock()
+	defer a.muApply.Unlock()
+	return a.applyManifestListLocked(prev, ml, force, trigger)
+}
+
+// applyManifestListLocked applies the manifest list with muApply held
+func (a *Agent) applyManifestListLocked(prev, ml *api.ManifestList, force bool, trigger api.Execution_Trigger) error {
+	ctx, done := a.startApply()
+	defer done()
+
 	logrus.Debug("applying manifest list")
 	a.status.Set(api.NodeStatus_UPDATING, "")
+	a.status.ResetAssemblies()
+	// failed attempts are counted per manifest list
+	if trigger != api.Execution_RETRY {
+		a.retries.reset(prev, ml)
+	}
+	// remove assemblies no longer in the manifest list for this node
+	if err := a.removeAssemblies(ctx, prev, ml, trigger); err != nil {
+		a.status.Set(api.NodeStatus_FAILURE, err.Error())
+		return err
+	}
 	// check assemblies and install if needed
-	for _, manifest := range ml.Manifests {
-		if err := a.applyManifest(manifest, force); err != nil {
-			a.status.Set(api.NodeStatus_FAILURE, err.Error())
-			return err
-		}
+	if err := a.applyAssemblies(ctx, ml, force, trigger); err != nil {
+		a.status.Set(api.NodeStatus_FAILURE, err.Error())
+		return err
+	}
+	if err := a.pruneAssemblyDirs(); err != nil {
+		logrus.WithError(err).Warn("error removing unused assembly content")
+	}
+	if _, err := a.pruneContent(ctx, false); err != nil {
+		logrus.WithError(err).Warn("error removing unused image content")
 	}
 	a.status.Set(api.NodeStatus_OK, "")
 
 	return nil
 }
 
-func (a *Agent) applyManifest(m *api.Manifest, force bool) error {
-	matches := false
-	// check if node id matches
-	if m.NodeID == "" && len(m.Labels) == 0 || a.config.NodeID == m.NodeID {
-		matches = true
-	}
-	// check labels
-	for k, v := range m.Labels {
-		if x, ok := a.config.Labels[k]; ok {
-			if x == "" || x == v {
-				matches = true
-				break
-			}
-		}
+// nodeAssemblies returns the assemblies in the manifest list that apply to this node
+func (a *Agent) nodeAssemblies(ml *api.ManifestList) ([]*api.Assembly, error) {
+	return a.renderAssemblies(manifest.NodeAssemblies(ml, a.config.NodeID, a.labels()))
+}
+
+// applyAssemblies applies the assemblies for this node in dependency order.
+// assemblies that do not depend on each other are applied concurrently up to
+// the configured apply concurrency.  assemblies that require a failed assembly
+// are skipped.  if the context is cancelled no further assemblies are started.
+func (a *Agent) applyAssemblies(ctx context.Context, ml *api.ManifestList, force bool, trigger api.Execution_Trigger) error {
+	assemblies, err := a.nodeAssemblies(ml)
+	if err != nil {
+		return err
+	}
+	a.status.SetRules(manifest.AssemblyRules(ml, a.config.NodeID, a.labels()))
+	graph := manifest.NewGraph(assemblies)
+	pending, err := graph.Sort()
+	if err != nil {
+		return err
 	}
 
-	if !matches {
-		return nil
+	limit := a.config.ApplyConcurrency
+	if limit < 1 {
+		limit = 1
 	}
 
-	var errs []string
-	for _, assembly := range m.Assemblies {
-		logrus.WithField("image", assembly.Image).Info("applying assembly")
-		a.status.Set(api.NodeStatus_UPDATING, fmt.Sprintf("applying assembly %s", assembly.Image))
-		// apply requires
-		for _, req := range assembly.Requires {
-			logrus.WithFields(logrus.Fields{
-				"image":    assembly.Image,
-				"required": req,
-			}).Info("applying required assembly")
-			output, err := a.applyAssembly(&api.Assembly{Image: req}, force)
-			if err != nil {
-				logrus.WithError(err).Errorf("error applying required assembly %s: %s", req, string(output))
-				errs = append(errs, err.Error())
+	type result struct {
+		image string
+		err   error
+	}
+
+	var (
+		results = make(chan result)
+		applied = map[string]bool{}
+		failed  = map[string]bool{}
+		running = 0
+		errs    []string
+	)
+	for len(pending) > 0 || running > 0 {
+		// pending is in dependency order so a failure cascades in a single pass
+		var waiting []*api.Assembly
+		for _, assembly := range pending {
+			if dep := failedDependency(graph, assembly.Image, failed); dep != "" {
+				logrus.WithFields(logrus.Fields{
+					"image":    assembly.Image,
+					"required": dep,
+				}).Warn("skipping assembly; required assembly failed")
+				a.status.SetAssembly(&api.AssemblyStatus{
+					Image:       assembly.Image,
+					Status:      api.AssemblyStatus_SKIPPED,
+					Description: fmt.Sprintf("required assembly %s failed", dep),
+				})
+				failed[assembly.Image] = true
+				continue
+			}
+			if ctx.Err() != nil {
+				a.status.SetAssembly(&api.AssemblyStatus{
+					Image:       assembly.Image,
+					Status:      api.AssemblyStatus_SKIPPED,
+					Description: ErrApplyCancelled.Error(),
+				})
+				failed[assembly.Image] = true
+				continue
+			}
+			// failed assemblies are retried once their next attempt is due
+			if state := a.retries.waiting(assembly.Image); trigger == api.Execution_RETRY && state != nil {
+				a.status.SetAssembly(state.status(assembly.Image))
+				failed[assembly.Image] = true
+				errs = append(errs, state.description)
+				continue
+			}
+			if running >= limit || !requirementsApplied(graph, assembly.Image, applied) {
+				waiting = append(waiting, assembly)
 				continue
 			}
+
+			running++
+			logrus.WithField("image", assembly.Image).Info("applying assembly")
+			a.status.StartAssembly(assembly.Image)
+			go func(assembly *api.Assembly) {
+				err := a.applyAssemblyStatus(ctx, assembly, force, trigger)
+				a.status.FinishAssembly(assembly.Image)
+				results <- result{image: assembly.Image, err: err}
+			}(assembly)
 		}
-		// apply assembly
-		output, err := a.applyAssembly(assembly, force)
-		if err != nil {
-			logrus.WithError(err).Errorf("error applying assembly %s: %s", assembly.Image, string(output))
-			errs = append(errs, err.Error())
+		pending = waiting
+
+		if running == 0 {
+			if ctx.Err() != nil {
+				errs = append(errs, ErrApplyCancelled.Error())
+			}
+			break
+		}
+
+		r := <-results
+		running--
+		if r.err != nil {
+			logrus.WithError(r.err).Errorf("error applying assembly %s", r.image)
+			failed[r.image] = true
+			errs = append(errs, r.err.Error())
 			continue
 		}
+		applied[r.image] = true
 
-		logrus.WithField("assembly", assembly.Image).Info("assembly applied successfully")
+		logrus.WithField("assembly", r.image).Info("assembly applied successfully")
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf(strings.Join(errs, ", "))
+		return errors.New(strings.Join(errs, ", "))
 	}
 	return nil
 }
 
-func (a *Agent) applyAssembly(assembly *api.Assembly, force bool) ([]byte, error) {
-	applied, err := a.assemblyApplied(assembly)
+// requirementsApplied returns true if every image required by image has been applied
+func requirementsApplied(graph *manifest.Graph, image string, applied map[string]bool) bool {
+	for _, req := range graph.Requires(image) {
+		if !applied[req] {
+			return false
+		}
+	}
+	return true
+}
+
+// failedDependency returns the first image required by image that failed or was skipped
+func failedDependency(graph *manifest.Graph, image string, failed map[string]bool) string {
+	for _, req := range graph.Requires(image) {
+		if failed[req] {
+			return req
+		}
+	}
+	return ""
+}
+
+// applyAssemblyStatus applies the assembly and records the result in the node status
+func (a *Agent) applyAssemblyStatus(ctx context.Context, assembly *api.Assembly, force bool, trigger api.Execution_Trigger) error {
+	record, err := a.applyAssembly(ctx, assembly, force, trigger)
+	if err != nil {
+		// cancelled assemblies are not retried
+		if errors.Cause(err) == ErrApplyCancelled {
+			a.status.SetAssembly(&api.AssemblyStatus{
+				Image:       assembly.Image,
+				Status:      api.AssemblyStatus_FAILURE,
+				Description: err.Error(),
+			})
+			return err
+		}
+		a.status.SetAssembly(a.retries.failed(assembly, err).status(assembly.Image))
+		return err
+	}
+	a.retries.succeeded(assembly.Image)
+	a.status.SetAssembly(&api.AssemblyStatus{
+		Image:  assembly.Image,
+		Status: api.AssemblyStatus_APPLIED,
+		Digest: record.Digest.String(),
+	})
+	return nil
+}
+
+// applyAssembly installs the assembly if the resolved image digest or the
+// parameters differ from the applied record.  every install attempt is
+// recorded as an execution.
+func (a *Agent) applyAssembly(ctx context.Context, assembly *api.Assembly, force bool, trigger api.Execution_Trigger) (record *assemblyRecord, err error) {
+	var output []byte
+	e := a.newExecution(assembly, "./install", trigger)
+	defer func() {
+		// assemblies that are already applied are not executed
+		if e != nil {
+			a.finishExecution(e, output, err)
+		}
+	}()
+
+	_, desc, err := newResolver().Resolve(ctx, assembly.Image)
 	if err != nil {
 		return nil, err
 	}
-	if applied && !force {
+	e.Digest = desc.Digest.String()
+
+	record, err = a.getAssemblyRecord(assembly.Image)
+	if err != nil {
 		return nil, err
 	}
-	tmpdir, err := ioutil.TempDir("", "terra-assembly-")
+	if record != nil && record.current(desc.Digest, a.assemblyParametersHash(assembly)) && !force {
+		logrus.WithFields(logrus.Fields{
+			"image":  assembly.Image,
+			"digest": desc.Digest,
+		}).Debug("assembly already applied")
+		e = nil
+		return record, nil
+	}
+	dir, err := a.executionDir(ctx, assembly.Image, desc.Digest)
 	if err != nil {
 		return nil, err
 	}
-	defer os.RemoveAll(tmpdir)
+	defer os.RemoveAll(dir)
 
-	if err := fetchImage(assembly.Image, tmpdir); err != nil {
+	output, err = a.execAssembly(ctx, assembly, dir, "./install", e)
+	if err != nil {
 		return nil, err
 	}
 
+	record = &assemblyRecord{
+		Image:          assembly.Image,
+		Digest:         desc.Digest,
+		ParametersHash: a.assemblyParametersHash(assembly),
+		Applied:        time.Now(),
+		Output:         output,
+	}
+	if err := a.putAssemblyRecord(record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// execAssembly runs the entrypoint from the extracted assembly in dir
+// and returns the combined output.  the output is also returned on failure.
+// the entrypoint is stopped if the context is cancelled or the assembly
+// timeout expires.  if e is specified it receives the resource usage.
+func (a *Agent) execAssembly(ctx context.Context, assembly *api.Assembly, dir, entrypoint string, e *api.Execution) ([]byte, error) {
+	timeout, err := a.assemblyTimeout(assembly)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := parseExecPolicy(assembly.Policy)
+	if err != nil {
+		return nil, err
+	}
 	nodePeers := []string{}
 	peers, err := a.clusterAgent.Peers()
 	if err != nil {
@@ -120,142 +297,105 @@ func (a *Agent) applyAssembly(assembly *api.Assembly, force bool) ([]byte, error
 	for _, peer := range peers {
 		nodePeers = append(nodePeers, peer.Address)
 	}
-	env := os.Environ()
+	env := policyEnv(assembly.Policy)
 	// add terra env vars
 	env = append(env, fmt.Sprintf("TERRA_NODE_ID=%s", a.clusterAgent.Self().ID))
 	env = append(env, fmt.Sprintf("TERRA_NODE_ADDR=%s", a.clusterAgent.Self().Address))
 	env = append(env, fmt.Sprintf("TERRA_NODE_PEERS=%s", strings.Join(nodePeers, ",")))
-	// add parameters
-	for k, v := range assembly.Parameters {
+	// add parameters with secret references resolved
+	params, secrets, err := a.resolveParameters(assembly.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range params {
 		env = append(env, fmt.Sprintf("TERRA_%s=%s", strings.ToUpper(k), v))
 	}
+	redact := newRedactor(secrets)
+
+	// stream output to log subscribers while it is buffered
+	logExec := a.logs.start(assembly.Image, entrypoint)
+	stdoutLog := a.logs.writer(logExec, "stdout", redact)
+	defer stdoutLog.Close()
+	stderrLog := a.logs.writer(logExec, "stderr", redact)
+	defer stderrLog.Close()
 
 	var stdout, stderr bytes.Buffer
-	// exec 'install' from package
-	cmd := exec.Command("./install")
-	cmd.Dir = tmpdir
+	// exec entrypoint from package
+	cmd := exec.Command(entrypoint)
+	cmd.Dir = dir
 	cmd.Env = env
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		out := append(stdout.Bytes(), stderr.Bytes()...)
-		return nil, errors.Wrap(err, string(out))
+	cmd.Stdout = io.MultiWriter(&stdout, stdoutLog)
+	cmd.Stderr = io.MultiWriter(&stderr, stderrLog)
+	if policy != nil {
+		if err := policy.apply(cmd); err != nil {
+			return nil, err
+		}
 	}
-
-	output := append(stdout.Bytes(), stderr.Bytes()...)
-
-	// update db
-	if err := a.db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte(bucketAssemblies))
-		return b.Put([]byte(assembly.Image), output)
-	}); err != nil {
-		return output, err
+	cg, err := a.newCgroup(a.resourceLimits(assembly))
+	if err != nil {
+		return nil, err
 	}
-
-	return output, nil
-}
-
-func (a *Agent) assemblyApplied(assembly *api.Assembly) (bool, error) {
-	applied := false
-	if err := a.db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte(bucketAssemblies))
-		v := b.Get([]byte(assembly.Image))
-		if v != nil {
-			applied = true
+	if cg != nil {
+		defer cg.remove()
+		if err := cg.apply(cmd); err != nil {
+			return nil, err
 		}
-		return nil
-	}); err != nil {
-		return false, err
 	}
 
-	return applied, nil
-}
-
-func fetchImage(imageName, dest string) error {
-	if _, err := os.Stat(dest); err != nil {
-		if !os.IsNotExist(err) {
-			return err
-		}
-
-		if err := os.MkdirAll(dest, 0755); err != nil {
-			return err
-		}
+	err = a.runCommand(ctx, cmd, timeout)
+	if cg != nil && e != nil {
+		e.Usage = cg.usage()
 	}
-	tmpContent, err := ioutil.TempDir("", "terra-content-")
+	// output is stored in records and statuses so secret values are redacted
+	out := []byte(redact(string(append(stdout.Bytes(), stderr.Bytes()...))))
 	if err != nil {
-		return err
+		return out, errors.Wrap(err, string(out))
 	}
-	defer os.RemoveAll(tmpContent)
 
-	cs, err := local.NewStore(tmpContent)
-	if err != nil {
-		return err
-	}
+	return out, nil
+}
 
+func newResolver() remotes.Resolver {
 	authorizer := docker.NewAuthorizer(nil, getDockerCredentials)
-	resolver := docker.NewResolver(docker.ResolverOptions{
+	return docker.NewResolver(docker.ResolverOptions{
 		Authorizer: authorizer,
 	})
-	ctx := context.Background()
-
-	name, desc, err := resolver.Resolve(ctx, imageName)
-	if err != nil {
-		return err
-	}
-	fetcher, err := resolver.Fetcher(ctx, name)
-	if err != nil {
-		return err
-	}
-	r, err := fetcher.Fetch(ctx, desc)
-	if err != nil {
-		return err
-	}
-	defer r.Close()
-
-	childrenHandler := images.ChildrenHandler(cs)
-	h := images.Handlers(remotes.FetchHandler(cs, fetcher), childrenHandler)
-	if err := images.Dispatch(ctx, h, desc); err != nil {
-		return err
-	}
+}
 
-	if err := cs.Walk(ctx, func(info content.Info) error {
-		desc := ocispec.Descriptor{
-			Digest: info.Digest,
-		}
-		ra, err := cs.ReaderAt(ctx, desc)
-		if err != nil {
-			return err
-		}
-		cr := content.NewReader(ra)
-		r, err := compression.DecompressStream(cr)
-		if err != nil {
-			return err
-		}
-		defer r.Close()
-		if r.(compression.DecompressReadCloser).GetCompression() == compression.Uncompressed {
-			return nil
-		}
-		if _, err := archive.Apply(ctx, dest, r); err != nil {
-			return err
-		}
-		return nil
-	}); err != nil {
-		return err
+// pinnedImage returns the image reference pinned to the digest
+func pinnedImage(image string, dgst digest.Digest) string {
+	if dgst == "" || strings.Contains(image, "@") {
+		return image
 	}
-
-	return nil
+	return image + "@" + dgst.String()
 }
 
-func (a *Agent) updateManifestList(ml *api.ManifestList, force bool) error {
+func (a *Agent) updateManifestList(ml *api.ManifestList, force bool, trigger api.Execution_Trigger) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	// update in memory copy
-	a.manifestList = ml
-
 	// persist to disk
 	if err := a.db.Update(func(tx *bolt.Tx) error {
+		// manifest lists synced from peers keep their revision unless the
+		// number is used by a different local revision
+		revision := uint64(0)
+		if trigger == api.Execution_SYNC && ml.Revision != 0 {
+			r, err := syncedRevision(tx, ml)
+			if err != nil {
+				return err
+			}
+			if r == 0 {
+				logrus.WithField("revision", ml.Revision).Warn("synced manifest list conflicts with local revision; storing as new revision")
+			}
+			revision = r
+		}
+		if revision == 0 {
+			revision = latestRevision(tx) + 1
+		}
+		ml.Revision = revision
+		if err := a.putRevision(tx, ml); err != nil {
+			return err
+		}
 		b := tx.Bucket([]byte(bucketState))
 		data, err := json.Marshal(ml)
 		if err != nil {
@@ -266,46 +406,23 @@ func (a *Agent) updateManifestList(ml *api.ManifestList, force bool) error {
 		return err
 	}
 
-	logrus.WithField("updated", ml.Updated).Info("updated manifest list")
+	// update in memory copy
+	prev := a.manifestList
+	a.manifestList = ml
+
+	logrus.WithFields(logrus.Fields{
+		"updated":  ml.Updated,
+		"revision": ml.Revision,
+	}).Info("updated manifest list")
 
-	//wg := &sync.WaitGroup{}
-	// apply assemblies in manifest
+	// apply assemblies in manifest.  force only applies again on this node;
+	// forced cluster updates are rolled out by the rollout controller.
 	go func() {
-		//wg.Add(1)
-		//defer wg.Done()
-		if err := a.applyManifestList(ml, force); err != nil {
+		if err := a.applyManifestList(prev, ml, force, trigger); err != nil {
 			logrus.WithError(err).Error("error applying manifest list")
 			return
 		}
 	}()
-	// if force is specified apply on entire cluster
-	if force {
-		//wg.Add(1)
-		//defer wg.Done()
-		peers, err := a.clusterAgent.Peers()
-		if err != nil {
-			return errors.Wrap(err, "error getting peers to update manifest list")
-		}
-
-		for _, peer := range peers {
-			go func() {
-				//wg.Add(1)
-				//defer wg.Done()
-				c, err := client.NewClient(peer.Address)
-				defer c.Close()
-				if err != nil {
-					logrus.WithError(err).Errorf("error getting client for peer %s", peer.Address)
-					return
-				}
-				if err := c.Apply(ml.Manifests, true); err != nil {
-					logrus.WithError(err).Errorf("error applying manifest list for peer %s", peer.Address)
-					return
-				}
-			}()
-		}
-	}
-
-	//wg.Wait()
 
 	return nil
 }
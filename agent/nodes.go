@@ -3,11 +3,17 @@ package agent
 import (
 	"context"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
 	"github.com/stellarproject/terra/client"
+	"github.com/stellarproject/terra/manifest"
 )
 
+// Nodes returns the cluster nodes matching the request selector
 func (a *Agent) Nodes(ctx context.Context, req *api.NodesRequest) (*api.NodesResponse, error) {
+	selector, err := manifest.ParseSelector(req.Selector)
+	if err != nil {
+		return nil, err
+	}
 	self := a.clusterAgent.Self()
 	peers, err := a.clusterAgent.Peers()
 	if err != nil {
@@ -18,11 +24,8 @@ func (a *Agent) Nodes(ctx context.Context, req *api.NodesRequest) (*api.NodesRes
 		{
 			ID:      self.ID,
 			Address: self.Address,
-			Labels:  self.Labels,
-			Status: &api.NodeStatus{
-				Status:      a.status.State(),
-				Description: a.status.Description(),
-			},
+			Labels:  a.labels(),
+			Status:  a.status.NodeStatus(),
 		},
 	}
 
@@ -35,16 +38,28 @@ func (a *Agent) Nodes(ctx context.Context, req *api.NodesRequest) (*api.NodesRes
 		if err != nil {
 			return nil, err
 		}
+		// peers report their labels including facts in the status
+		labels := nodeStatus.Labels
+		if labels == nil {
+			labels = peer.Labels
+		}
 		nodes = append(nodes, &api.Node{
 			ID:      peer.ID,
 			Address: peer.Address,
-			Labels:  peer.Labels,
+			Labels:  labels,
 			Status:  nodeStatus,
 		})
 		c.Close()
 	}
 
+	matched := []*api.Node{}
+	for _, n := range nodes {
+		if selector.Matches(n.Labels) {
+			matched = append(matched, n)
+		}
+	}
+
 	return &api.NodesResponse{
-		Nodes: nodes,
+		Nodes: matched,
 	}, nil
 }
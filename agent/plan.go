@@ -0,0 +1,146 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	"github.com/stellarproject/terra/manifest"
+)
+
+// Plan returns the actions the node, or every node in the cluster, would
+// take to apply the manifest list.  nothing is applied.
+func (a *Agent) Plan(ctx context.Context, req *api.PlanRequest) (*api.PlanResponse, error) {
+	if err := manifest.Validate(req.ManifestList).Err(); err != nil {
+		return nil, err
+	}
+	nodes := []*api.NodePlan{
+		a.plan(req.ManifestList, req.Force),
+	}
+
+	if req.Cluster {
+		peers, err := a.clusterAgent.Peers()
+		if err != nil {
+			return nil, err
+		}
+		for _, peer := range peers {
+			nodes = append(nodes, planPeer(peer.ID, peer.Address, req.ManifestList, req.Force))
+		}
+	}
+
+	return &api.PlanResponse{
+		Nodes: nodes,
+	}, nil
+}
+
+func planPeer(id, address string, ml *api.ManifestList, force bool) *api.NodePlan {
+	c, err := client.NewClient(address)
+	if err != nil {
+		return &api.NodePlan{NodeID: id, Error: err.Error()}
+	}
+	defer c.Close()
+
+	plans, err := c.Plan(ml, force, false)
+	if err != nil {
+		return &api.NodePlan{NodeID: id, Error: err.Error()}
+	}
+	if len(plans) == 0 {
+		return &api.NodePlan{NodeID: id}
+	}
+	return plans[0]
+}
+
+// plan returns the planned actions for this node in the order they would be applied
+func (a *Agent) plan(ml *api.ManifestList, force bool) *api.NodePlan {
+	p := &api.NodePlan{
+		NodeID: a.config.NodeID,
+	}
+
+	uninstall, err := a.planRemovals(a.manifestList, ml)
+	if err != nil {
+		p.Error = err.Error()
+		return p
+	}
+	p.Assemblies = append(p.Assemblies, uninstall...)
+
+	// parameter templates are evaluated for the node so template errors are
+	// reported by the plan instead of failing the install
+	assemblies, err := a.nodeAssemblies(ml)
+	if err != nil {
+		p.Error = err.Error()
+		return p
+	}
+	assemblies, err = manifest.NewGraph(assemblies).Sort()
+	if err != nil {
+		p.Error = err.Error()
+		return p
+	}
+	rules := manifest.AssemblyRules(ml, a.config.NodeID, a.labels())
+	resolver := newResolver()
+	for _, assembly := range assemblies {
+		planned := &api.PlannedAssembly{
+			Image: assembly.Image,
+			Rule:  rules[assembly.Image],
+		}
+		p.Assemblies = append(p.Assemblies, planned)
+
+		record, err := a.getAssemblyRecord(assembly.Image)
+		if err != nil {
+			planned.Reason = err.Error()
+			continue
+		}
+		if record != nil {
+			planned.AppliedDigest = record.Digest.String()
+		}
+		_, desc, err := resolver.Resolve(context.Background(), assembly.Image)
+		if err != nil {
+			logrus.WithError(err).Warnf("error resolving %s for plan", assembly.Image)
+			planned.Reason = err.Error()
+			continue
+		}
+		planned.Digest = desc.Digest.String()
+
+		switch {
+		case record == nil:
+			planned.Action = api.PlannedAssembly_INSTALL
+			planned.Reason = "not applied"
+		case force:
+			planned.Action = api.PlannedAssembly_REAPPLY
+			planned.Reason = "force"
+		case record.Digest != desc.Digest:
+			planned.Action = api.PlannedAssembly_REAPPLY
+			planned.Reason = "digest changed"
+		case record.ParametersHash != a.assemblyParametersHash(assembly):
+			planned.Action = api.PlannedAssembly_REAPPLY
+			planned.Reason = "parameters changed"
+		default:
+			planned.Action = api.PlannedAssembly_SKIP
+			planned.Reason = "up to date"
+		}
+	}
+
+	return p
+}
+
+// planRemovals returns the assemblies that would be uninstalled in removal order
+func (a *Agent) planRemovals(prev, ml *api.ManifestList) ([]*api.PlannedAssembly, error) {
+	planned := []*api.PlannedAssembly{}
+	for _, assembly := range a.removedAssemblies(prev, ml) {
+		image := assembly.Image
+		record, err := a.getAssemblyRecord(image)
+		if err != nil {
+			return nil, err
+		}
+		if record == nil {
+			continue
+		}
+		planned = append(planned, &api.PlannedAssembly{
+			Image:         image,
+			Action:        api.PlannedAssembly_UNINSTALL,
+			AppliedDigest: record.Digest.String(),
+			Reason:        "no longer in manifest list",
+		})
+	}
+	return planned, nil
+}
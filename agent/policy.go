@@ -0,0 +1,118 @@
+package agent
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+const (
+	defaultPolicyPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+)
+
+// execPolicy is the parsed execution policy of an assembly
+type execPolicy struct {
+	credential *syscall.Credential
+	namespaces bool
+	readOnly   bool
+	writable   []string
+}
+
+// parseExecPolicy returns the execution policy for the assembly or nil if
+// the assembly runs unrestricted
+func parseExecPolicy(p *api.ExecutionPolicy) (*execPolicy, error) {
+	if p == nil {
+		return nil, nil
+	}
+	policy := &execPolicy{
+		namespaces: p.Namespaces || p.ReadOnly,
+		readOnly:   p.ReadOnly,
+	}
+	if p.User != "" {
+		cred, err := lookupCredential(p.User)
+		if err != nil {
+			return nil, err
+		}
+		policy.credential = cred
+	}
+	for _, w := range p.Writable {
+		if !strings.HasPrefix(w, "/") {
+			return nil, errors.Errorf("writable path %s must be absolute", w)
+		}
+		policy.writable = append(policy.writable, w)
+	}
+	return policy, nil
+}
+
+// lookupCredential returns the credential for uid[:gid] or user[:group].  the
+// primary group of the user is used if no group is specified.
+func lookupCredential(s string) (*syscall.Credential, error) {
+	parts := strings.SplitN(s, ":", 2)
+	uid, err := strconv.ParseUint(parts[0], 10, 32)
+	gid := uint64(0)
+	if err != nil {
+		u, err := user.Lookup(parts[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid policy user %s", s)
+		}
+		if uid, err = strconv.ParseUint(u.Uid, 10, 32); err != nil {
+			return nil, err
+		}
+		if gid, err = strconv.ParseUint(u.Gid, 10, 32); err != nil {
+			return nil, err
+		}
+	} else if u, err := user.LookupId(parts[0]); err == nil {
+		if gid, err = strconv.ParseUint(u.Gid, 10, 32); err != nil {
+			return nil, err
+		}
+	} else {
+		gid = uid
+	}
+
+	if len(parts) == 2 {
+		if gid, err = strconv.ParseUint(parts[1], 10, 32); err != nil {
+			g, err := user.LookupGroup(parts[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid policy group %s", s)
+			}
+			if gid, err = strconv.ParseUint(g.Gid, 10, 32); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &syscall.Credential{
+		Uid:    uint32(uid),
+		Gid:    uint32(gid),
+		Groups: []uint32{},
+	}, nil
+}
+
+// policyEnv returns the agent environment passed to entrypoints.  without a
+// policy the entire agent environment is passed; otherwise only the allowed
+// variables and a default PATH.
+func policyEnv(p *api.ExecutionPolicy) []string {
+	if p == nil {
+		return os.Environ()
+	}
+	env := []string{}
+	hasPath := false
+	for _, name := range p.Env {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if name == "PATH" {
+			hasPath = true
+		}
+		env = append(env, name+"="+v)
+	}
+	if !hasPath {
+		env = append(env, "PATH="+defaultPolicyPath)
+	}
+	return env
+}
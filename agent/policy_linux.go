@@ -0,0 +1,191 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// execInitName is the process name of the agent binary when started to
+	// run an entrypoint with a read only host view
+	execInitName = "terra-exec-init"
+)
+
+// remountFlags maps the statfs flags preserved when remounting read only to
+// their mount flags; the ST_ values differ from the MS_ values
+var remountFlags = []struct {
+	st    int64
+	mount uintptr
+}{
+	{0x2, syscall.MS_NOSUID},       // ST_NOSUID
+	{0x4, syscall.MS_NODEV},        // ST_NODEV
+	{0x8, syscall.MS_NOEXEC},       // ST_NOEXEC
+	{0x400, syscall.MS_NOATIME},    // ST_NOATIME
+	{0x800, syscall.MS_NODIRATIME}, // ST_NODIRATIME
+	{0x1000, syscall.MS_RELATIME},  // ST_RELATIME
+}
+
+// execInitConfig is passed to the exec init process
+type execInitConfig struct {
+	Entrypoint string              `json:"entrypoint"`
+	Proc       bool                `json:"proc"`
+	ReadOnly   bool                `json:"read_only"`
+	Writable   []string            `json:"writable"`
+	Credential *syscall.Credential `json:"credential"`
+}
+
+// apply configures the command to run with the policy
+func (p *execPolicy) apply(cmd *exec.Cmd) error {
+	attr := cmd.SysProcAttr
+	if attr == nil {
+		attr = &syscall.SysProcAttr{}
+		cmd.SysProcAttr = attr
+	}
+	if !p.namespaces {
+		attr.Credential = p.credential
+		return nil
+	}
+	// unsharing the mount namespace makes the mounts private to the entrypoint
+	attr.Cloneflags |= syscall.CLONE_NEWPID
+	attr.Unshareflags |= syscall.CLONE_NEWNS | syscall.CLONE_NEWUTS
+
+	// /proc is mounted for the new pid namespace and the host is remounted by
+	// the exec init process which drops privileges before executing the
+	// entrypoint
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cfg, err := json.Marshal(&execInitConfig{
+		Entrypoint: cmd.Path,
+		Proc:       true,
+		ReadOnly:   p.readOnly,
+		Writable:   p.writable,
+		Credential: p.credential,
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Path = self
+	cmd.Args = []string{execInitName, string(cfg)}
+	return nil
+}
+
+// ExecInitRequested returns true if the process was started by the agent to
+// run an entrypoint in new namespaces
+func ExecInitRequested() bool {
+	return len(os.Args) == 2 && os.Args[0] == execInitName
+}
+
+// ExecInit mounts /proc for the pid namespace, remounts the host read only
+// apart from the writable paths, drops privileges and executes the entrypoint.
+// it only returns on error.
+func ExecInit() error {
+	var cfg *execInitConfig
+	if err := json.Unmarshal([]byte(os.Args[1]), &cfg); err != nil {
+		return err
+	}
+	if cfg.Proc {
+		// the host /proc shows the host processes
+		if err := syscall.Mount("proc", "/proc", "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+			return errors.Wrap(err, "error mounting /proc")
+		}
+	}
+	if cfg.ReadOnly {
+		if err := readOnlyHost(cfg.Writable); err != nil {
+			return errors.Wrap(err, "error remounting host read only")
+		}
+	}
+	if c := cfg.Credential; c != nil {
+		if err := syscall.Setgroups([]int{}); err != nil {
+			return err
+		}
+		if err := syscall.Setgid(int(c.Gid)); err != nil {
+			return err
+		}
+		if err := syscall.Setuid(int(c.Uid)); err != nil {
+			return err
+		}
+	}
+	return syscall.Exec(cfg.Entrypoint, []string{cfg.Entrypoint}, os.Environ())
+}
+
+// readOnlyHost remounts every mount read only except the writable paths and
+// the kernel filesystems.  it must be run in a private mount namespace.
+func readOnlyHost(writable []string) error {
+	keep := []string{"/proc", "/sys", "/dev"}
+	for _, w := range writable {
+		w = filepath.Clean(w)
+		// bind the path onto itself so it is a separate writable mount
+		if err := syscall.Mount(w, w, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+			return errors.Wrapf(err, "error binding writable path %s", w)
+		}
+		keep = append(keep, w)
+	}
+
+	mounts, err := mountPoints()
+	if err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if underAny(m, keep) {
+			continue
+		}
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(m, &st); err != nil {
+			return err
+		}
+		flags := uintptr(syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY)
+		for _, f := range remountFlags {
+			if int64(st.Flags)&f.st != 0 {
+				flags |= f.mount
+			}
+		}
+		if err := syscall.Mount("", m, "", flags, ""); err != nil {
+			return errors.Wrapf(err, "error remounting %s", m)
+		}
+	}
+	return nil
+}
+
+// mountPoints returns the mount points of the current mount namespace
+func mountPoints() ([]string, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mounts []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mounts = append(mounts, unescapeMountPoint(fields[4]))
+	}
+	return mounts, s.Err()
+}
+
+// unescapeMountPoint decodes the octal escapes used in mountinfo
+func unescapeMountPoint(s string) string {
+	return strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`).Replace(s)
+}
+
+// underAny returns true if the path is one of the paths or below one of them
+func underAny(path string, paths []string) bool {
+	for _, p := range paths {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
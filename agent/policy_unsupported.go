@@ -0,0 +1,34 @@
+//go:build !linux
+// +build !linux
+
+package agent
+
+import (
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// apply configures the command to run with the policy
+func (p *execPolicy) apply(cmd *exec.Cmd) error {
+	if p.namespaces {
+		return errors.New("execution policy namespaces are only supported on linux")
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = p.credential
+	return nil
+}
+
+// ExecInitRequested returns true if the process was started by the agent to
+// run an entrypoint with a read only host view
+func ExecInitRequested() bool {
+	return false
+}
+
+// ExecInit is only supported on linux
+func ExecInit() error {
+	return errors.New("exec init is only supported on linux")
+}
@@ -0,0 +1,133 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/manifest"
+)
+
+const (
+	// DriftPolicyReport only reports drifted assemblies in the node status
+	DriftPolicyReport = "report"
+	// DriftPolicyReinstall runs the install entrypoint again for drifted assemblies
+	DriftPolicyReinstall = "reinstall"
+)
+
+// reconcile checks the applied assemblies for drift every reconcile interval
+func (a *Agent) reconcile() {
+	t := time.NewTicker(a.config.ReconcileInterval)
+	for range t.C {
+		// skip if currently updating
+		if a.status.IsUpdating() {
+			continue
+		}
+		if err := a.checkAssemblies(); err != nil {
+			logrus.WithError(err).Error("error checking assemblies")
+		}
+	}
+}
+
+// checkAssemblies runs the check entrypoint for each applied assembly in
+// dependency order.  assemblies that fail the check are reported as drifted
+// and reinstalled if the drift policy is reinstall.
+func (a *Agent) checkAssemblies() error {
+	// an apply in progress will check the assemblies itself
+	if !a.muApply.TryLock() {
+		return nil
+	}
+	defer a.muApply.Unlock()
+	ctx, done := a.startApply()
+	defer done()
+
+	a.mu.Lock()
+	ml := a.manifestList
+	a.mu.Unlock()
+
+	assemblies, err := a.nodeAssemblies(ml)
+	if err != nil {
+		return err
+	}
+	assemblies, err = manifest.NewGraph(assemblies).Sort()
+	if err != nil {
+		return err
+	}
+
+	var (
+		reinstalled bool
+		errs        []string
+	)
+	for _, assembly := range assemblies {
+		record, err := a.getAssemblyRecord(assembly.Image)
+		if err != nil {
+			return err
+		}
+		if record == nil || record.Digest == "" {
+			continue
+		}
+		if err := a.checkAssembly(ctx, assembly, record); err != nil {
+			logrus.WithError(err).WithField("image", assembly.Image).Warn("assembly drift detected")
+			a.status.SetAssembly(&api.AssemblyStatus{
+				Image:       assembly.Image,
+				Status:      api.AssemblyStatus_DRIFTED,
+				Description: err.Error(),
+				Digest:      record.Digest.String(),
+			})
+			if a.config.DriftPolicy != DriftPolicyReinstall {
+				continue
+			}
+
+			reinstalled = true
+			logrus.WithField("image", assembly.Image).Info("reinstalling drifted assembly")
+			a.status.StartAssembly(assembly.Image)
+			err := a.applyAssemblyStatus(ctx, assembly, true, api.Execution_RECONCILE)
+			a.status.FinishAssembly(assembly.Image)
+			if err != nil {
+				errs = append(errs, err.Error())
+			}
+			continue
+		}
+		// clear a previously reported drift
+		if s := a.status.Assembly(assembly.Image); s != nil && s.Status == api.AssemblyStatus_DRIFTED {
+			a.status.SetAssembly(&api.AssemblyStatus{
+				Image:  assembly.Image,
+				Status: api.AssemblyStatus_APPLIED,
+				Digest: record.Digest.String(),
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		err := errors.New(strings.Join(errs, ", "))
+		a.status.Set(api.NodeStatus_FAILURE, err.Error())
+		return err
+	}
+	if reinstalled {
+		a.status.Set(api.NodeStatus_OK, "")
+	}
+	return nil
+}
+
+// checkAssembly runs the check entrypoint from the applied content of the
+// assembly.  assemblies without a check entrypoint always pass.
+func (a *Agent) checkAssembly(ctx context.Context, assembly *api.Assembly, record *assemblyRecord) error {
+	dir, err := a.executionDir(ctx, assembly.Image, record.Digest)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if _, err := os.Stat(filepath.Join(dir, "check")); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	_, err = a.execAssembly(ctx, assembly, dir, "./check", nil)
+	return err
+}
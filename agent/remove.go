@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/manifest"
+)
+
+// removedAssemblies returns the assemblies for this node in the previous
+// manifest list that are no longer present in the new one.  assemblies are
+// returned before the assemblies they require.
+func (a *Agent) removedAssemblies(prev, ml *api.ManifestList) []*api.Assembly {
+	current := map[string]bool{}
+	for _, assembly := range manifest.NodeAssemblies(ml, a.config.NodeID, a.labels()) {
+		current[assembly.Image] = true
+	}
+
+	assemblies, err := a.nodeAssemblies(prev)
+	if err != nil {
+		// uninstall with the parameters as written if the templates no longer evaluate
+		logrus.WithError(err).Warn("error evaluating previous assembly parameters")
+		assemblies = manifest.NodeAssemblies(prev, a.config.NodeID, a.labels())
+	}
+	previous, err := manifest.NewGraph(assemblies).Sort()
+	if err != nil {
+		// the previous list was never applied in dependency order
+		previous = assemblies
+	}
+
+	removed := []*api.Assembly{}
+	for i := len(previous) - 1; i >= 0; i-- {
+		if !current[previous[i].Image] {
+			removed = append(removed, previous[i])
+		}
+	}
+	return removed
+}
+
+// removeAssemblies uninstalls the assemblies that were applied to this node
+// from the previous manifest list and are no longer present in the new one
+func (a *Agent) removeAssemblies(ctx context.Context, prev, ml *api.ManifestList, trigger api.Execution_Trigger) error {
+	var errs []string
+	for _, assembly := range a.removedAssemblies(prev, ml) {
+		image := assembly.Image
+		record, err := a.getAssemblyRecord(image)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			continue
+		}
+
+		logrus.WithField("image", image).Info("removing assembly")
+		a.status.Set(api.NodeStatus_UPDATING, fmt.Sprintf("removing assembly %s", image))
+		if err := a.removeAssembly(ctx, assembly, record, trigger); err != nil {
+			logrus.WithError(err).Errorf("error removing assembly %s", image)
+			a.status.SetAssembly(&api.AssemblyStatus{
+				Image:       image,
+				Status:      api.AssemblyStatus_FAILURE,
+				Description: err.Error(),
+				Digest:      record.Digest.String(),
+			})
+			errs = append(errs, err.Error())
+			continue
+		}
+		a.status.SetAssembly(&api.AssemblyStatus{
+			Image:  image,
+			Status: api.AssemblyStatus_REMOVED,
+			Digest: record.Digest.String(),
+		})
+
+		logrus.WithField("assembly", image).Info("assembly removed successfully")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// removeAssembly runs the uninstall entrypoint from the applied image
+// digest and removes the assembly record.  assemblies without an uninstall
+// entrypoint are only removed from the record.
+func (a *Agent) removeAssembly(ctx context.Context, assembly *api.Assembly, record *assemblyRecord, trigger api.Execution_Trigger) (err error) {
+	var output []byte
+	e := a.newExecution(assembly, "./uninstall", trigger)
+	e.Digest = record.Digest.String()
+	defer func() {
+		if e != nil {
+			a.finishExecution(e, output, err)
+		}
+	}()
+
+	dir, err := a.executionDir(ctx, assembly.Image, record.Digest)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := os.Stat(filepath.Join(dir, "uninstall")); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		logrus.WithField("image", assembly.Image).Warn("assembly has no uninstall; removing record only")
+		e = nil
+	} else {
+		output, err = a.execAssembly(ctx, assembly, dir, "./uninstall", e)
+		if err != nil {
+			return err
+		}
+		logrus.WithField("image", assembly.Image).Debugf("uninstall output: %s", string(output))
+	}
+
+	return a.deleteAssemblyRecord(assembly.Image)
+}
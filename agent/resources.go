@@ -0,0 +1,67 @@
+package agent
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// resourceLimits returns the agent resource limits overridden by the limits
+// set on the assembly
+func (a *Agent) resourceLimits(assembly *api.Assembly) *api.ResourceLimits {
+	limits := &api.ResourceLimits{}
+	if r := a.config.Resources; r != nil {
+		*limits = *r
+	}
+	if r := assembly.Resources; r != nil {
+		if r.CPU != "" {
+			limits.CPU = r.CPU
+		}
+		if r.Memory != "" {
+			limits.Memory = r.Memory
+		}
+		if r.Pids != 0 {
+			limits.Pids = r.Pids
+		}
+		if len(r.IO) > 0 {
+			limits.IO = r.IO
+		}
+	}
+	return limits
+}
+
+// hasLimits returns true if any resource limit is set
+func hasLimits(limits *api.ResourceLimits) bool {
+	return limits.CPU != "" || limits.Memory != "" || limits.Pids != 0 || len(limits.IO) > 0
+}
+
+// parseCPU returns the cgroup cpu.max value for the number of CPUs
+func parseCPU(s string) (string, error) {
+	cpus, err := strconv.ParseFloat(s, 64)
+	if err != nil || cpus <= 0 {
+		return "", errors.Errorf("invalid cpu limit %q", s)
+	}
+	const period = 100000
+	return strconv.FormatInt(int64(cpus*period), 10) + " " + strconv.Itoa(period), nil
+}
+
+// parseBytes returns the number of bytes for a value with an optional K, M, G or T suffix
+func parseBytes(s string) (uint64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	v = strings.TrimSuffix(v, "B")
+	multiplier := uint64(1)
+	for i, suffix := range []string{"K", "M", "G", "T"} {
+		if strings.HasSuffix(v, suffix) {
+			multiplier = 1 << (10 * uint(i+1))
+			v = strings.TrimSuffix(v, suffix)
+			break
+		}
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid byte value %q", s)
+	}
+	return n * multiplier, nil
+}
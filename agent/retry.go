@@ -0,0 +1,163 @@
+package agent
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/manifest"
+)
+
+const (
+	retryInterval = time.Second
+)
+
+// retryState is the retry state of a failed assembly
+type retryState struct {
+	attempts    uint32
+	description string
+	// next is the time of the next attempt; zero if no retry is scheduled
+	next time.Time
+}
+
+// status returns the assembly status for the failed assembly
+func (s *retryState) status(image string) *api.AssemblyStatus {
+	status := &api.AssemblyStatus{
+		Image:       image,
+		Status:      api.AssemblyStatus_FAILURE,
+		Description: s.description,
+		Attempts:    s.attempts,
+	}
+	if !s.next.IsZero() {
+		next := s.next
+		status.NextRetry = &next
+	}
+	return status
+}
+
+// retries tracks the failed attempts of assemblies for the last applied
+// manifest list.  retries apply that list again with its previous list so the
+// assemblies it removed are uninstalled as well.
+type retries struct {
+	mu           *sync.Mutex
+	assemblies   map[string]*retryState
+	prev         *api.ManifestList
+	manifestList *api.ManifestList
+}
+
+func newRetries() *retries {
+	return &retries{
+		mu:         &sync.Mutex{},
+		assemblies: map[string]*retryState{},
+	}
+}
+
+// failed records a failed attempt and schedules the next attempt if allowed
+// by the assembly retry policy
+func (r *retries) failed(assembly *api.Assembly, err error) *retryState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.assemblies[assembly.Image]
+	if !ok {
+		s = &retryState{}
+		r.assemblies[assembly.Image] = s
+	}
+	s.attempts++
+	s.description = err.Error()
+	s.next = time.Time{}
+
+	policy, perr := manifest.ParseRetry(assembly.Retry)
+	if perr != nil {
+		logrus.WithError(perr).Warnf("not retrying assembly %s", assembly.Image)
+	}
+	if policy != nil && int(s.attempts) < policy.MaxAttempts {
+		s.next = time.Now().Add(policy.Delay(int(s.attempts), rand.Float64()))
+	}
+	state := *s
+	return &state
+}
+
+// succeeded clears the failed attempts for the image
+func (r *retries) succeeded(image string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.assemblies, image)
+}
+
+// reset clears the failed attempts of all assemblies and records the manifest
+// list being applied and its previous list for retries
+func (r *retries) reset(prev, ml *api.ManifestList) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.assemblies = map[string]*retryState{}
+	r.prev = prev
+	r.manifestList = ml
+}
+
+// lists returns the manifest list to retry and its previous list
+func (r *retries) lists() (*api.ManifestList, *api.ManifestList) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.prev, r.manifestList
+}
+
+// waiting returns the retry state if the image failed and is not yet due for
+// another attempt
+func (r *retries) waiting(image string) *retryState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.assemblies[image]
+	if !ok || (!s.next.IsZero() && !s.next.After(time.Now())) {
+		return nil
+	}
+	state := *s
+	return &state
+}
+
+// due returns true if any failed assembly is due for another attempt
+func (r *retries) due() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, s := range r.assemblies {
+		if !s.next.IsZero() && !s.next.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// retry applies the manifest list that failed again when a failed assembly is
+// due for another attempt
+func (a *Agent) retry() {
+	t := time.NewTicker(retryInterval)
+	for range t.C {
+		// skip if currently updating
+		if a.status.IsUpdating() || !a.retries.due() {
+			continue
+		}
+		a.retryManifestList()
+	}
+}
+
+// retryManifestList applies the failed manifest list with its previous list.
+// the lists are read with muApply held so a newer list applied in the
+// meantime is never replaced by the failed list.
+func (a *Agent) retryManifestList() {
+	// an apply in progress resets or retries the failed assemblies itself
+	if !a.muApply.TryLock() {
+		return
+	}
+	defer a.muApply.Unlock()
+
+	prev, ml := a.retries.lists()
+	if ml == nil || !a.retries.due() {
+		return
+	}
+	logrus.Info("retrying failed assemblies")
+	if err := a.applyManifestListLocked(prev, ml, false, api.Execution_RETRY); err != nil {
+		logrus.WithError(err).Error("error retrying manifest list")
+	}
+}
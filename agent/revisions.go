@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	defaultRevisionHistory = 10
+)
+
+// History returns the stored manifest list revisions
+func (a *Agent) History(ctx context.Context, req *api.HistoryRequest) (*api.HistoryResponse, error) {
+	revisions, err := a.getRevisions()
+	if err != nil {
+		return nil, err
+	}
+	return &api.HistoryResponse{
+		Revisions: revisions,
+	}, nil
+}
+
+func revisionKey(revision uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, revision)
+	return k
+}
+
+// latestRevision returns the highest stored revision
+func latestRevision(tx *bolt.Tx) uint64 {
+	k, _ := tx.Bucket([]byte(bucketRevisions)).Cursor().Last()
+	if k == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(k)
+}
+
+// putRevision stores the manifest list as its revision and removes the oldest
+// revisions beyond the configured retention
+func (a *Agent) putRevision(tx *bolt.Tx, ml *api.ManifestList) error {
+	b := tx.Bucket([]byte(bucketRevisions))
+	data, err := json.Marshal(ml)
+	if err != nil {
+		return err
+	}
+	if err := b.Put(revisionKey(ml.Revision), data); err != nil {
+		return err
+	}
+	return a.pruneRevisions(tx)
+}
+
+func (a *Agent) pruneRevisions(tx *bolt.Tx) error {
+	retention := a.config.RevisionHistory
+	if retention < 1 {
+		retention = defaultRevisionHistory
+	}
+	b := tx.Bucket([]byte(bucketRevisions))
+	var keys [][]byte
+	if err := b.ForEach(func(k, v []byte) error {
+		keys = append(keys, append([]byte{}, k...))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for i := 0; i < len(keys)-retention; i++ {
+		logrus.WithField("revision", binary.BigEndian.Uint64(keys[i])).Debug("removing manifest list revision")
+		if err := b.Delete(keys[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeRevisions stores the revisions replicated from a peer that are not present locally.
+// a peer revision with the number of a different local revision was accepted
+// by both nodes at the same time and is stored as a new revision.
+func (a *Agent) storeRevisions(revisions []*api.ManifestList) error {
+	return a.db.Update(func(tx *bolt.Tx) error {
+		for _, ml := range revisions {
+			if ml.Revision == 0 {
+				continue
+			}
+			revision, err := syncedRevision(tx, ml)
+			if err != nil {
+				return err
+			}
+			if revision != 0 {
+				// the revision is stored unless it is already present
+				if tx.Bucket([]byte(bucketRevisions)).Get(revisionKey(revision)) == nil {
+					if err := a.putRevision(tx, ml); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			logrus.WithField("revision", ml.Revision).Warn("peer revision conflicts with local revision; storing as new revision")
+			renumbered := *ml
+			renumbered.Revision = latestRevision(tx) + 1
+			if err := a.putRevision(tx, &renumbered); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// syncedRevision returns the revision number to store a manifest list from a
+// peer as.  the local revision is returned if the same manifest list is
+// already stored, the peer revision if the number is unused and zero if the
+// number is used by a different manifest list.
+func syncedRevision(tx *bolt.Tx, ml *api.ManifestList) (uint64, error) {
+	b := tx.Bucket([]byte(bucketRevisions))
+	var (
+		revision uint64
+		used     bool
+	)
+	if err := b.ForEach(func(k, v []byte) error {
+		var local *api.ManifestList
+		if err := json.Unmarshal(v, &local); err != nil {
+			return err
+		}
+		if sameRevision(local, ml) && revision == 0 {
+			revision = local.Revision
+		}
+		if local.Revision == ml.Revision {
+			used = true
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	switch {
+	case revision != 0:
+		return revision, nil
+	case !used:
+		return ml.Revision, nil
+	}
+	return 0, nil
+}
+
+// sameRevision returns true if the manifest lists were accepted as the same
+// revision regardless of the revision number
+func sameRevision(a, b *api.ManifestList) bool {
+	if !a.Updated.Equal(b.Updated) || len(a.Manifests) != len(b.Manifests) {
+		return false
+	}
+	for i := range a.Manifests {
+		if !proto.Equal(a.Manifests[i], b.Manifests[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// getRevisions returns the stored manifest list revisions in order
+func (a *Agent) getRevisions() ([]*api.ManifestList, error) {
+	var revisions []*api.ManifestList
+	if err := a.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketRevisions))
+		return b.ForEach(func(k, v []byte) error {
+			var ml *api.ManifestList
+			if err := json.Unmarshal(v, &ml); err != nil {
+				return err
+			}
+			revisions = append(revisions, ml)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
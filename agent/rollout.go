@@ -0,0 +1,277 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	"github.com/stellarproject/terra/manifest"
+)
+
+const (
+	defaultRolloutNodeTimeout = 10 * time.Minute
+	rolloutStatusInterval     = 2 * time.Second
+)
+
+var (
+	// ErrRolloutInProgress is returned when a rollout is started while another is running
+	ErrRolloutInProgress = errors.New("rollout already in progress")
+	// ErrRolloutHalted is returned when a rollout is stopped due to node failures
+	ErrRolloutHalted = errors.New("rollout halted")
+)
+
+// Rollout applies the manifest list to the cluster in batches.  if a canary is
+// specified the canary nodes are updated and checked first.  the manifest list
+// is only published to the cluster once every batch has been updated.
+func (a *Agent) Rollout(req *api.RolloutRequest, stream api.Terra_RolloutServer) error {
+	if !a.muRollout.TryLock() {
+		return ErrRolloutInProgress
+	}
+	defer a.muRollout.Unlock()
+
+	if err := manifest.Validate(req.ManifestList).Err(); err != nil {
+		return err
+	}
+
+	nodes, err := a.rolloutNodes()
+	if err != nil {
+		return err
+	}
+
+	r := newRollout(a, req, stream.Send)
+	if req.Canary != nil {
+		canaries, rest, err := selectCanaries(nodes, req.Canary)
+		if err != nil {
+			return err
+		}
+		if err := r.canary(canaries, req.Canary, a.manifestList); err != nil {
+			return err
+		}
+		r.event(api.RolloutEvent_PROMOTED, "", fmt.Sprintf("promoting to %d nodes", len(rest)))
+		nodes = rest
+	}
+	if err := r.run(nodes); err != nil {
+		return err
+	}
+
+	// publish the manifest list to the cluster
+	req.ManifestList.Updated = time.Now()
+	if err := a.updateManifestList(req.ManifestList, false, api.Execution_UPDATE); err != nil {
+		return err
+	}
+	r.event(api.RolloutEvent_COMPLETE, "", "manifest list published")
+
+	return nil
+}
+
+// rolloutNode is a cluster node targeted by a rollout
+type rolloutNode struct {
+	ID      string
+	Address string
+	Labels  map[string]string
+	self    bool
+}
+
+// rolloutNodes returns this node and all peers sorted by id.  node labels
+// include the facts so canaries can be selected by them.
+func (a *Agent) rolloutNodes() ([]*rolloutNode, error) {
+	self := a.clusterAgent.Self()
+	nodes := []*rolloutNode{
+		{
+			ID:      self.ID,
+			Address: self.Address,
+			Labels:  a.labels(),
+			self:    true,
+		},
+	}
+	peers, err := a.peerNodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, peer := range peers {
+		nodes = append(nodes, &rolloutNode{
+			ID:      peer.ID,
+			Address: peer.Address,
+			Labels:  peer.Labels,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes, nil
+}
+
+// rollout applies a manifest list to cluster nodes in batches
+type rollout struct {
+	agent          *Agent
+	manifestList   *api.ManifestList
+	force          bool
+	batchSize      int
+	maxUnavailable int
+	maxFailures    int
+	nodeTimeout    time.Duration
+	send           func(*api.RolloutEvent) error
+
+	mu     *sync.Mutex
+	batch  int
+	failed int
+}
+
+func newRollout(a *Agent, req *api.RolloutRequest, send func(*api.RolloutEvent) error) *rollout {
+	r := &rollout{
+		agent:          a,
+		manifestList:   req.ManifestList,
+		force:          req.Force,
+		batchSize:      int(req.BatchSize),
+		maxUnavailable: int(req.MaxUnavailable),
+		maxFailures:    int(req.MaxFailures),
+		nodeTimeout:    req.NodeTimeout,
+		send:           send,
+		mu:             &sync.Mutex{},
+	}
+	if r.batchSize < 1 {
+		r.batchSize = 1
+	}
+	if r.maxUnavailable < 1 {
+		r.maxUnavailable = r.batchSize
+	}
+	if r.nodeTimeout == 0 {
+		r.nodeTimeout = defaultRolloutNodeTimeout
+	}
+	return r
+}
+
+// event sends the rollout event to the caller
+func (r *rollout) event(t api.RolloutEvent_Type, nodeID, desc string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	logrus.WithFields(logrus.Fields{
+		"batch": r.batch,
+		"node":  nodeID,
+		"event": api.RolloutEvent_Type_name[int32(t)],
+	}).Info(desc)
+	if err := r.send(&api.RolloutEvent{
+		Type:        t,
+		Batch:       uint32(r.batch),
+		NodeID:      nodeID,
+		Description: desc,
+		Timestamp:   time.Now(),
+	}); err != nil {
+		logrus.WithError(err).Warn("error sending rollout event")
+	}
+}
+
+// run updates the nodes in batches.  each batch must complete before the next
+// is started.  the rollout is halted when the failed nodes exceed max failures
+// or no nodes can be updated without exceeding max unavailable.
+func (r *rollout) run(nodes []*rolloutNode) error {
+	for i := 0; i < len(nodes); i += r.batchSize {
+		end := i + r.batchSize
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+		r.batch++
+
+		// failed nodes remain unavailable for the rest of the rollout
+		slots := r.maxUnavailable - r.failed
+		if slots < 1 {
+			r.event(api.RolloutEvent_HALTED, "", fmt.Sprintf("%d failed nodes reached max unavailable %d", r.failed, r.maxUnavailable))
+			return ErrRolloutHalted
+		}
+		r.event(api.RolloutEvent_BATCH_STARTED, "", fmt.Sprintf("updating %d nodes", end-i))
+
+		sem := make(chan struct{}, slots)
+		wg := &sync.WaitGroup{}
+		for _, node := range nodes[i:end] {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(node *rolloutNode) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				r.event(api.RolloutEvent_NODE_UPDATING, node.ID, "updating node")
+				if err := r.updateNode(node); err != nil {
+					r.mu.Lock()
+					r.failed++
+					r.mu.Unlock()
+					r.event(api.RolloutEvent_NODE_FAILED, node.ID, err.Error())
+					return
+				}
+				r.event(api.RolloutEvent_NODE_OK, node.ID, "node updated")
+			}(node)
+		}
+		wg.Wait()
+
+		if r.failed > r.maxFailures {
+			r.event(api.RolloutEvent_HALTED, "", fmt.Sprintf("%d failed nodes exceeded max failures %d", r.failed, r.maxFailures))
+			return ErrRolloutHalted
+		}
+		r.event(api.RolloutEvent_BATCH_COMPLETE, "", "batch complete")
+	}
+
+	return nil
+}
+
+// updateNode applies the manifest list to the node and waits for the node
+// status to return to OK
+func (r *rollout) updateNode(node *rolloutNode) error {
+	if node.self {
+		return r.agent.applyManifestList(nil, r.manifestList, r.force, applyTrigger(r.force))
+	}
+
+	c, err := client.NewClient(node.Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// closing the client does not stop the apply on the node so it is
+	// cancelled through the api if the node does not respond in time
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.Apply(r.manifestList.Manifests, r.force)
+	}()
+	timeout := time.After(r.nodeTimeout)
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return err
+		}
+	case <-timeout:
+		cancelNode(c, node.ID)
+		return errors.Errorf("timeout applying manifest list after %s", r.nodeTimeout)
+	}
+
+	t := time.NewTicker(rolloutStatusInterval)
+	defer t.Stop()
+	for {
+		status, err := c.Status()
+		if err != nil {
+			return err
+		}
+		switch status.Status {
+		case api.NodeStatus_OK:
+			return nil
+		case api.NodeStatus_FAILURE:
+			return errors.New(status.Description)
+		}
+		select {
+		case <-t.C:
+		case <-timeout:
+			cancelNode(c, node.ID)
+			return errors.Errorf("timeout waiting for node status after %s", r.nodeTimeout)
+		}
+	}
+}
+
+// cancelNode aborts the in progress apply on the connected node so the node
+// is not still applying when the rollout continues or rolls back
+func cancelNode(c *client.Client, id string) {
+	if err := c.Cancel(""); err != nil {
+		logrus.WithError(err).Warnf("error cancelling apply on node %s", id)
+	}
+}
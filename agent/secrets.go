@@ -0,0 +1,453 @@
+package agent
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// secretPrefix references a secret from an assembly parameter value
+	secretPrefix = "secret:"
+	redacted     = "********"
+	// minSecretLength is the shortest value that is redacted from output;
+	// shorter values would replace common words and digits in every line
+	minSecretLength = 4
+)
+
+var (
+	// ErrSecretsNotConfigured is returned when the agent has no secret key
+	ErrSecretsNotConfigured = errors.New("secret key not configured")
+	// ErrSecretNotFound is returned for unknown secrets
+	ErrSecretNotFound = errors.New("secret not found")
+)
+
+// secretRecord is the encrypted secret stored in bucketSecrets
+type secretRecord struct {
+	Name    string    `json:"name"`
+	Data    []byte    `json:"data"`
+	Updated time.Time `json:"updated"`
+	Deleted bool      `json:"deleted"`
+}
+
+// additionalData returns the record fields authenticated with the sealed value
+func (r *secretRecord) additionalData() []byte {
+	return []byte(fmt.Sprintf("%s\x00%d\x00%t", r.Name, r.Updated.UnixNano(), r.Deleted))
+}
+
+func (r *secretRecord) secret() *api.Secret {
+	return &api.Secret{
+		Name:    r.Name,
+		Updated: r.Updated,
+		Deleted: r.Deleted,
+	}
+}
+
+// SetSecret encrypts and stores the secret and replicates it to the cluster
+func (a *Agent) SetSecret(ctx context.Context, req *api.SetSecretRequest) (*ptypes.Empty, error) {
+	if req.Name == "" {
+		return empty, errors.New("secret name required")
+	}
+	if len(req.Value) < minSecretLength {
+		return empty, errors.Errorf("secret value must be at least %d bytes to be redacted from output", minSecretLength)
+	}
+	r := &secretRecord{
+		Name:    req.Name,
+		Updated: time.Now(),
+	}
+	if err := a.encryptSecret(r, req.Value); err != nil {
+		return empty, err
+	}
+	if err := a.putSecret(r); err != nil {
+		return empty, err
+	}
+	a.replicateSecret(r)
+	return empty, nil
+}
+
+// GetSecret returns the decrypted secret
+func (a *Agent) GetSecret(ctx context.Context, req *api.GetSecretRequest) (*api.GetSecretResponse, error) {
+	record, err := a.getSecretRecord(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	value, err := a.decryptSecret(record)
+	if err != nil {
+		return nil, err
+	}
+	return &api.GetSecretResponse{
+		Secret: record.secret(),
+		Value:  value,
+	}, nil
+}
+
+// RemoveSecret removes the secret and replicates the removal to the cluster
+func (a *Agent) RemoveSecret(ctx context.Context, req *api.RemoveSecretRequest) (*ptypes.Empty, error) {
+	if _, err := a.getSecretRecord(req.Name); err != nil {
+		return empty, err
+	}
+	// the removal is sealed with an empty value so peers can authenticate it
+	r := &secretRecord{
+		Name:    req.Name,
+		Updated: time.Now(),
+		Deleted: true,
+	}
+	if err := a.encryptSecret(r, nil); err != nil {
+		return empty, err
+	}
+	if err := a.putSecret(r); err != nil {
+		return empty, err
+	}
+	a.replicateSecret(r)
+	return empty, nil
+}
+
+// ReplicateSecret stores the sealed secret from a peer if it is newer than the
+// local copy.  the secret is rejected unless it opens with the cluster key.
+func (a *Agent) ReplicateSecret(ctx context.Context, req *api.ReplicateSecretRequest) (*ptypes.Empty, error) {
+	if req.Secret == nil {
+		return empty, errors.New("secret required")
+	}
+	r, err := a.openSealedSecret(req.Secret)
+	if err != nil {
+		return empty, err
+	}
+	if _, err := a.putSecretIfNewer(r); err != nil {
+		return empty, err
+	}
+	return empty, nil
+}
+
+// SealedSecrets returns the stored secrets, including removed secrets, sealed
+// with the cluster key
+func (a *Agent) SealedSecrets(ctx context.Context, req *api.SealedSecretsRequest) (*api.SealedSecretsResponse, error) {
+	records, err := a.getSecretRecords()
+	if err != nil {
+		return nil, err
+	}
+	secrets := []*api.SealedSecret{}
+	for _, r := range records {
+		sealed, err := a.sealedSecret(r)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, sealed)
+	}
+	return &api.SealedSecretsResponse{
+		Secrets: secrets,
+	}, nil
+}
+
+// Secrets returns the stored secrets, including removed secrets, without their values
+func (a *Agent) Secrets(ctx context.Context, req *api.SecretsRequest) (*api.SecretsResponse, error) {
+	records, err := a.getSecretRecords()
+	if err != nil {
+		return nil, err
+	}
+	secrets := []*api.Secret{}
+	for _, r := range records {
+		secrets = append(secrets, r.secret())
+	}
+	return &api.SecretsResponse{
+		Secrets: secrets,
+	}, nil
+}
+
+// replicateSecret sends the sealed secret to every peer
+func (a *Agent) replicateSecret(r *secretRecord) {
+	sealed, err := a.sealedSecret(r)
+	if err != nil {
+		logrus.WithError(err).Errorf("error sealing secret %s for replication", r.Name)
+		return
+	}
+	peers, err := a.clusterAgent.Peers()
+	if err != nil {
+		logrus.WithError(err).Error("error getting peers to replicate secret")
+		return
+	}
+	for _, peer := range peers {
+		if peer.ID == a.config.NodeID {
+			continue
+		}
+		c, err := client.NewClient(peer.Address)
+		if err != nil {
+			logrus.WithError(err).Errorf("error getting client for peer %s", peer.Address)
+			continue
+		}
+		if err := c.ReplicateSecret(sealed); err != nil {
+			logrus.WithError(err).Errorf("error replicating secret to peer %s", peer.ID)
+		}
+		c.Close()
+	}
+}
+
+// syncSecrets replicates the sealed secrets from the peer that are newer than
+// the local copies
+func (a *Agent) syncSecrets(c *client.Client) error {
+	if a.config.SecretKey == nil {
+		return nil
+	}
+	secrets, err := c.SealedSecrets()
+	if err != nil {
+		return err
+	}
+	for _, s := range secrets {
+		r, err := a.openSealedSecret(s)
+		if err != nil {
+			return err
+		}
+		ok, err := a.putSecretIfNewer(r)
+		if err != nil {
+			return err
+		}
+		if ok {
+			logrus.WithField("secret", s.Name).Debug("replicated secret from peer")
+		}
+	}
+	return nil
+}
+
+// sealedSecret returns the stored secret for replication to a peer
+func (a *Agent) sealedSecret(r *secretRecord) (*api.SealedSecret, error) {
+	gcm, err := a.secretCipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Data) < gcm.NonceSize() {
+		return nil, errors.Errorf("invalid secret %s", r.Name)
+	}
+	return &api.SealedSecret{
+		Name:       r.Name,
+		Nonce:      r.Data[:gcm.NonceSize()],
+		Ciphertext: r.Data[gcm.NonceSize():],
+		Updated:    r.Updated,
+		Deleted:    r.Deleted,
+	}, nil
+}
+
+// openSealedSecret returns the record for the secret from a peer.  the secret
+// is authenticated with the cluster key so records sealed with another key or
+// modified in transit are rejected.
+func (a *Agent) openSealedSecret(s *api.SealedSecret) (*secretRecord, error) {
+	gcm, err := a.secretCipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Nonce) != gcm.NonceSize() {
+		return nil, errors.Errorf("invalid nonce for secret %s", s.Name)
+	}
+	r := &secretRecord{
+		Name:    s.Name,
+		Data:    append(append([]byte{}, s.Nonce...), s.Ciphertext...),
+		Updated: s.Updated,
+		Deleted: s.Deleted,
+	}
+	if _, err := a.decryptSecret(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (a *Agent) secretCipher() (cipher.AEAD, error) {
+	if a.config.SecretKey == nil {
+		return nil, ErrSecretsNotConfigured
+	}
+	block, err := aes.NewCipher(a.config.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptSecret sets the record data to the nonce and sealed value.  the name,
+// update time and removal are authenticated so a value cannot be moved to
+// another secret and a replicated record cannot be altered.
+func (a *Agent) encryptSecret(r *secretRecord, value []byte) error {
+	gcm, err := a.secretCipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	r.Data = gcm.Seal(nonce, nonce, value, r.additionalData())
+	return nil
+}
+
+func (a *Agent) decryptSecret(r *secretRecord) ([]byte, error) {
+	gcm, err := a.secretCipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Data) < gcm.NonceSize() {
+		return nil, errors.Errorf("invalid secret %s", r.Name)
+	}
+	nonce, data := r.Data[:gcm.NonceSize()], r.Data[gcm.NonceSize():]
+	value, err := gcm.Open(nil, nonce, data, r.additionalData())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decrypting secret %s", r.Name)
+	}
+	return value, nil
+}
+
+// putSecretIfNewer stores the secret if it was updated after the local copy
+// and returns true if it was stored
+func (a *Agent) putSecretIfNewer(r *secretRecord) (bool, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return false, err
+	}
+	stored := false
+	if err := a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketSecrets))
+		if v := b.Get([]byte(r.Name)); v != nil {
+			var local *secretRecord
+			if err := json.Unmarshal(v, &local); err != nil {
+				return err
+			}
+			if !r.Updated.After(local.Updated) {
+				return nil
+			}
+		}
+		stored = true
+		return b.Put([]byte(r.Name), data)
+	}); err != nil {
+		return false, err
+	}
+	return stored, nil
+}
+
+func (a *Agent) putSecret(r *secretRecord) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return a.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketSecrets)).Put([]byte(r.Name), data)
+	})
+}
+
+// getSecretRecord returns the stored secret or ErrSecretNotFound if the secret
+// does not exist or was removed
+func (a *Agent) getSecretRecord(name string) (*secretRecord, error) {
+	var r *secretRecord
+	if err := a.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket([]byte(bucketSecrets)).Get([]byte(name))
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &r)
+	}); err != nil {
+		return nil, err
+	}
+	if r == nil || r.Deleted {
+		return r, errors.Wrap(ErrSecretNotFound, name)
+	}
+	return r, nil
+}
+
+func (a *Agent) getSecretRecords() ([]*secretRecord, error) {
+	var records []*secretRecord
+	if err := a.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketSecrets)).ForEach(func(k, v []byte) error {
+			var r *secretRecord
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			records = append(records, r)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// secretName returns the secret referenced by the parameter value
+func secretName(value string) (string, bool) {
+	if !strings.HasPrefix(value, secretPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(value, secretPrefix), true
+}
+
+// resolveParameters returns the parameters with secret references replaced by
+// the decrypted values and the values to redact from output
+func (a *Agent) resolveParameters(params map[string]string) (map[string]string, []string, error) {
+	resolved := make(map[string]string, len(params))
+	var secrets []string
+	for k, v := range params {
+		name, ok := secretName(v)
+		if !ok {
+			resolved[k] = v
+			continue
+		}
+		r, err := a.getSecretRecord(name)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "parameter %s", k)
+		}
+		value, err := a.decryptSecret(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved[k] = string(value)
+		if len(value) > 0 {
+			secrets = append(secrets, string(value))
+		}
+	}
+	return resolved, secrets, nil
+}
+
+// parameterVersions returns the parameters with secret references versioned by
+// the secret update time so rotating a secret changes the parameters hash
+// without exposing the value
+func (a *Agent) parameterVersions(params map[string]string) map[string]string {
+	versions := make(map[string]string, len(params))
+	for k, v := range params {
+		versions[k] = v
+		name, ok := secretName(v)
+		if !ok {
+			continue
+		}
+		if r, err := a.getSecretRecord(name); err == nil {
+			versions[k] = fmt.Sprintf("%s@%d", v, r.Updated.UnixNano())
+		}
+	}
+	return versions
+}
+
+// newRedactor returns a func replacing the secret values in s.  values shorter
+// than minSecretLength are not redacted.
+func newRedactor(secrets []string) func(string) string {
+	oldnew := []string{}
+	// replace longer values first so a secret containing another is fully redacted
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	for _, s := range secrets {
+		if len(s) < minSecretLength {
+			logrus.Warnf("not redacting secret value shorter than %d bytes", minSecretLength)
+			continue
+		}
+		oldnew = append(oldnew, s, redacted)
+	}
+	if len(oldnew) == 0 {
+		return func(s string) string { return s }
+	}
+	return strings.NewReplacer(oldnew...).Replace
+}
@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRedactor(t *testing.T) {
+	redact := newRedactor([]string{"hunter2", "hunter22", "yes"})
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"password hunter2", "password " + redacted},
+		{"password hunter22", "password " + redacted},
+		{"yes, no secrets here", "yes, no secrets here"},
+	}
+	for _, c := range cases {
+		if s := redact(c.input); s != c.expected {
+			t.Errorf("expected %q; received %q", c.expected, s)
+		}
+	}
+
+	if s := newRedactor(nil)("output"); s != "output" {
+		t.Errorf("expected output unchanged; received %q", s)
+	}
+}
+
+func TestSecretAdditionalData(t *testing.T) {
+	a := &Agent{
+		config: &AgentConfig{
+			SecretKey: []byte("0123456789abcdef0123456789abcdef"),
+		},
+	}
+	updated := time.Now()
+	r := &secretRecord{
+		Name:    "db-password",
+		Updated: updated,
+	}
+	if err := a.encryptSecret(r, []byte("hunter2")); err != nil {
+		t.Fatal(err)
+	}
+	value, err := a.decryptSecret(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "hunter2" {
+		t.Fatalf("expected hunter2; received %q", value)
+	}
+
+	cases := []struct {
+		name   string
+		record *secretRecord
+	}{
+		{"name", &secretRecord{Name: "other", Data: r.Data, Updated: updated}},
+		{"updated", &secretRecord{Name: r.Name, Data: r.Data, Updated: updated.Add(time.Second)}},
+		{"deleted", &secretRecord{Name: r.Name, Data: r.Data, Updated: updated, Deleted: true}},
+	}
+	for _, c := range cases {
+		if _, err := a.decryptSecret(c.record); err == nil {
+			t.Errorf("expected error decrypting secret with changed %s", c.name)
+		}
+	}
+}
@@ -3,14 +3,13 @@ package agent
 import (
 	"context"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
 )
 
 func (a *Agent) Status(ctx context.Context, req *api.StatusRequest) (*api.StatusResponse, error) {
+	nodeStatus := a.status.NodeStatus()
+	nodeStatus.Labels = a.labels()
 	return &api.StatusResponse{
-		NodeStatus: &api.NodeStatus{
-			Status:      a.status.State(),
-			Description: a.status.Description(),
-		},
+		NodeStatus: nodeStatus,
 	}, nil
 }
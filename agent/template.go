@@ -0,0 +1,51 @@
+package agent
+
+import (
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/manifest"
+)
+
+// templateData returns the node and cluster state parameter templates are
+// evaluated with
+func (a *Agent) templateData() (*manifest.TemplateData, error) {
+	peers, err := a.peerNodes()
+	if err != nil {
+		return nil, err
+	}
+	self := a.clusterAgent.Self()
+	return &manifest.TemplateData{
+		Node: manifest.Node{
+			ID:      self.ID,
+			Address: self.Address,
+			Labels:  a.labels(),
+		},
+		Peers: peers,
+		Facts: a.facts(),
+	}, nil
+}
+
+// renderAssemblies returns the assemblies with the parameter templates evaluated
+// for this node.  the cluster state is only gathered if there are templates.
+func (a *Agent) renderAssemblies(assemblies []*api.Assembly) ([]*api.Assembly, error) {
+	var data *manifest.TemplateData
+	rendered := make([]*api.Assembly, 0, len(assemblies))
+	for _, assembly := range assemblies {
+		if !manifest.Templated(assembly) {
+			rendered = append(rendered, assembly)
+			continue
+		}
+		if data == nil {
+			d, err := a.templateData()
+			if err != nil {
+				return nil, err
+			}
+			data = d
+		}
+		r, err := manifest.RenderParameters(assembly, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, r)
+	}
+	return rendered, nil
+}
@@ -5,13 +5,17 @@ import (
 	"time"
 
 	ptypes "github.com/gogo/protobuf/types"
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/manifest"
 )
 
 func (a *Agent) Update(ctx context.Context, req *api.UpdateRequest) (*ptypes.Empty, error) {
+	if err := manifest.Validate(req.ManifestList).Err(); err != nil {
+		return empty, err
+	}
 	req.ManifestList.Updated = time.Now()
 
-	if err := a.updateManifestList(req.ManifestList, req.Force); err != nil {
+	if err := a.updateManifestList(req.ManifestList, req.Force, applyTrigger(req.Force)); err != nil {
 		return empty, err
 	}
 
@@ -0,0 +1,4507 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: github.com/stellarproject/terra/api/v1/terra.proto
+
+package v1 // import "github.com/stellarproject/terra/api/v1"
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// skipping weak import gogoproto "github.com/gogo/protobuf/gogoproto"
+import types "github.com/gogo/protobuf/types"
+
+import time "time"
+
+import context "golang.org/x/net/context"
+import grpc "google.golang.org/grpc"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type NodeStatus_Status int32
+
+const (
+	NodeStatus_UNKNOWN  NodeStatus_Status = 0
+	NodeStatus_OK       NodeStatus_Status = 1
+	NodeStatus_UPDATING NodeStatus_Status = 2
+	NodeStatus_FAILURE  NodeStatus_Status = 3
+)
+
+var NodeStatus_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "OK",
+	2: "UPDATING",
+	3: "FAILURE",
+}
+var NodeStatus_Status_value = map[string]int32{
+	"UNKNOWN":  0,
+	"OK":       1,
+	"UPDATING": 2,
+	"FAILURE":  3,
+}
+
+func (x NodeStatus_Status) String() string {
+	return proto.EnumName(NodeStatus_Status_name, int32(x))
+}
+func (NodeStatus_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{14, 0}
+}
+
+type AssemblyStatus_Status int32
+
+const (
+	AssemblyStatus_UNKNOWN AssemblyStatus_Status = 0
+	AssemblyStatus_APPLIED AssemblyStatus_Status = 1
+	AssemblyStatus_REMOVED AssemblyStatus_Status = 2
+	AssemblyStatus_FAILURE AssemblyStatus_Status = 3
+	AssemblyStatus_SKIPPED AssemblyStatus_Status = 4
+	// DRIFTED assemblies failed the check entrypoint
+	AssemblyStatus_DRIFTED AssemblyStatus_Status = 5
+)
+
+var AssemblyStatus_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "APPLIED",
+	2: "REMOVED",
+	3: "FAILURE",
+	4: "SKIPPED",
+	5: "DRIFTED",
+}
+var AssemblyStatus_Status_value = map[string]int32{
+	"UNKNOWN": 0,
+	"APPLIED": 1,
+	"REMOVED": 2,
+	"FAILURE": 3,
+	"SKIPPED": 4,
+	"DRIFTED": 5,
+}
+
+func (x AssemblyStatus_Status) String() string {
+	return proto.EnumName(AssemblyStatus_Status_name, int32(x))
+}
+func (AssemblyStatus_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{15, 0}
+}
+
+type RolloutEvent_Type int32
+
+const (
+	RolloutEvent_UNKNOWN          RolloutEvent_Type = 0
+	RolloutEvent_BATCH_STARTED    RolloutEvent_Type = 1
+	RolloutEvent_NODE_UPDATING    RolloutEvent_Type = 2
+	RolloutEvent_NODE_OK          RolloutEvent_Type = 3
+	RolloutEvent_NODE_FAILED      RolloutEvent_Type = 4
+	RolloutEvent_BATCH_COMPLETE   RolloutEvent_Type = 5
+	RolloutEvent_COMPLETE         RolloutEvent_Type = 6
+	RolloutEvent_HALTED           RolloutEvent_Type = 7
+	RolloutEvent_CANARY_STARTED   RolloutEvent_Type = 8
+	RolloutEvent_CANARY_HEALTHY   RolloutEvent_Type = 9
+	RolloutEvent_CANARY_UNHEALTHY RolloutEvent_Type = 10
+	RolloutEvent_PROMOTED         RolloutEvent_Type = 11
+	RolloutEvent_ABORTED          RolloutEvent_Type = 12
+)
+
+var RolloutEvent_Type_name = map[int32]string{
+	0:  "UNKNOWN",
+	1:  "BATCH_STARTED",
+	2:  "NODE_UPDATING",
+	3:  "NODE_OK",
+	4:  "NODE_FAILED",
+	5:  "BATCH_COMPLETE",
+	6:  "COMPLETE",
+	7:  "HALTED",
+	8:  "CANARY_STARTED",
+	9:  "CANARY_HEALTHY",
+	10: "CANARY_UNHEALTHY",
+	11: "PROMOTED",
+	12: "ABORTED",
+}
+var RolloutEvent_Type_value = map[string]int32{
+	"UNKNOWN":          0,
+	"BATCH_STARTED":    1,
+	"NODE_UPDATING":    2,
+	"NODE_OK":          3,
+	"NODE_FAILED":      4,
+	"BATCH_COMPLETE":   5,
+	"COMPLETE":         6,
+	"HALTED":           7,
+	"CANARY_STARTED":   8,
+	"CANARY_HEALTHY":   9,
+	"CANARY_UNHEALTHY": 10,
+	"PROMOTED":         11,
+	"ABORTED":          12,
+}
+
+func (x RolloutEvent_Type) String() string {
+	return proto.EnumName(RolloutEvent_Type_name, int32(x))
+}
+func (RolloutEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{20, 0}
+}
+
+type PlannedAssembly_Action int32
+
+const (
+	PlannedAssembly_UNKNOWN   PlannedAssembly_Action = 0
+	PlannedAssembly_INSTALL   PlannedAssembly_Action = 1
+	PlannedAssembly_REAPPLY   PlannedAssembly_Action = 2
+	PlannedAssembly_SKIP      PlannedAssembly_Action = 3
+	PlannedAssembly_UNINSTALL PlannedAssembly_Action = 4
+)
+
+var PlannedAssembly_Action_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "INSTALL",
+	2: "REAPPLY",
+	3: "SKIP",
+	4: "UNINSTALL",
+}
+var PlannedAssembly_Action_value = map[string]int32{
+	"UNKNOWN":   0,
+	"INSTALL":   1,
+	"REAPPLY":   2,
+	"SKIP":      3,
+	"UNINSTALL": 4,
+}
+
+func (x PlannedAssembly_Action) String() string {
+	return proto.EnumName(PlannedAssembly_Action_name, int32(x))
+}
+func (PlannedAssembly_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{28, 0}
+}
+
+type Execution_Trigger int32
+
+const (
+	Execution_UNKNOWN   Execution_Trigger = 0
+	Execution_UPDATE    Execution_Trigger = 1
+	Execution_SYNC      Execution_Trigger = 2
+	Execution_FORCE     Execution_Trigger = 3
+	Execution_RECONCILE Execution_Trigger = 4
+	Execution_RETRY     Execution_Trigger = 5
+)
+
+var Execution_Trigger_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "UPDATE",
+	2: "SYNC",
+	3: "FORCE",
+	4: "RECONCILE",
+	5: "RETRY",
+}
+var Execution_Trigger_value = map[string]int32{
+	"UNKNOWN":   0,
+	"UPDATE":    1,
+	"SYNC":      2,
+	"FORCE":     3,
+	"RECONCILE": 4,
+	"RETRY":     5,
+}
+
+func (x Execution_Trigger) String() string {
+	return proto.EnumName(Execution_Trigger_name, int32(x))
+}
+func (Execution_Trigger) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{33, 0}
+}
+
+type Execution_Result int32
+
+const (
+	Execution_NONE      Execution_Result = 0
+	Execution_SUCCESS   Execution_Result = 1
+	Execution_FAILURE   Execution_Result = 2
+	Execution_CANCELLED Execution_Result = 3
+	Execution_TIMEOUT   Execution_Result = 4
+)
+
+var Execution_Result_name = map[int32]string{
+	0: "NONE",
+	1: "SUCCESS",
+	2: "FAILURE",
+	3: "CANCELLED",
+	4: "TIMEOUT",
+}
+var Execution_Result_value = map[string]int32{
+	"NONE":      0,
+	"SUCCESS":   1,
+	"FAILURE":   2,
+	"CANCELLED": 3,
+	"TIMEOUT":   4,
+}
+
+func (x Execution_Result) String() string {
+	return proto.EnumName(Execution_Result_name, int32(x))
+}
+func (Execution_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{33, 1}
+}
+
+type ListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{0}
+}
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(dst, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+type ListResponse struct {
+	ManifestList         *ManifestList `protobuf:"bytes,1,opt,name=manifest_list,json=manifestList" json:"manifest_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListResponse) Reset()         { *m = ListResponse{} }
+func (m *ListResponse) String() string { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()    {}
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{1}
+}
+func (m *ListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
+}
+func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResponse.Merge(dst, src)
+}
+func (m *ListResponse) XXX_Size() int {
+	return xxx_messageInfo_ListResponse.Size(m)
+}
+func (m *ListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListResponse proto.InternalMessageInfo
+
+func (m *ListResponse) GetManifestList() *ManifestList {
+	if m != nil {
+		return m.ManifestList
+	}
+	return nil
+}
+
+type Assembly struct {
+	Image      string            `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Requires   []string          `protobuf:"bytes,2,rep,name=requires" json:"requires,omitempty"`
+	Parameters map[string]string `protobuf:"bytes,3,rep,name=parameters" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// timeout is the maximum duration of an entrypoint (e.g. 10m); the agent default is used if empty
+	Timeout string `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// retry is the retry policy for the assembly; the manifest policy is used if empty
+	Retry *RetryPolicy `protobuf:"bytes,5,opt,name=retry" json:"retry,omitempty"`
+	// policy is the execution policy for the assembly; the manifest policy is used if empty
+	Policy *ExecutionPolicy `protobuf:"bytes,6,opt,name=policy" json:"policy,omitempty"`
+	// resources overrides the agent resource limits for the assembly entrypoints
+	Resources *ResourceLimits `protobuf:"bytes,7,opt,name=resources" json:"resources,omitempty"`
+	// variables are available to parameter templates as .Vars and override the manifest variables
+	Variables            map[string]string `protobuf:"bytes,8,rep,name=variables" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Assembly) Reset()         { *m = Assembly{} }
+func (m *Assembly) String() string { return proto.CompactTextString(m) }
+func (*Assembly) ProtoMessage()    {}
+func (*Assembly) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{2}
+}
+func (m *Assembly) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Assembly.Unmarshal(m, b)
+}
+func (m *Assembly) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Assembly.Marshal(b, m, deterministic)
+}
+func (dst *Assembly) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Assembly.Merge(dst, src)
+}
+func (m *Assembly) XXX_Size() int {
+	return xxx_messageInfo_Assembly.Size(m)
+}
+func (m *Assembly) XXX_DiscardUnknown() {
+	xxx_messageInfo_Assembly.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Assembly proto.InternalMessageInfo
+
+func (m *Assembly) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Assembly) GetRequires() []string {
+	if m != nil {
+		return m.Requires
+	}
+	return nil
+}
+
+func (m *Assembly) GetParameters() map[string]string {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *Assembly) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+func (m *Assembly) GetRetry() *RetryPolicy {
+	if m != nil {
+		return m.Retry
+	}
+	return nil
+}
+
+func (m *Assembly) GetPolicy() *ExecutionPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *Assembly) GetResources() *ResourceLimits {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *Assembly) GetVariables() map[string]string {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+type ResourceLimits struct {
+	// cpu is the number of CPUs (e.g. 0.5)
+	CPU string `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// memory is the memory limit in bytes with an optional K, M, G or T suffix
+	Memory string `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Pids   uint64 `protobuf:"varint,3,opt,name=pids,proto3" json:"pids,omitempty"`
+	// io are cgroup io.max entries (e.g. "8:0 rbps=1048576 wbps=1048576")
+	IO                   []string `protobuf:"bytes,4,rep,name=io" json:"io,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceLimits) Reset()         { *m = ResourceLimits{} }
+func (m *ResourceLimits) String() string { return proto.CompactTextString(m) }
+func (*ResourceLimits) ProtoMessage()    {}
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{3}
+}
+func (m *ResourceLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceLimits.Unmarshal(m, b)
+}
+func (m *ResourceLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceLimits.Marshal(b, m, deterministic)
+}
+func (dst *ResourceLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceLimits.Merge(dst, src)
+}
+func (m *ResourceLimits) XXX_Size() int {
+	return xxx_messageInfo_ResourceLimits.Size(m)
+}
+func (m *ResourceLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceLimits proto.InternalMessageInfo
+
+func (m *ResourceLimits) GetCPU() string {
+	if m != nil {
+		return m.CPU
+	}
+	return ""
+}
+
+func (m *ResourceLimits) GetMemory() string {
+	if m != nil {
+		return m.Memory
+	}
+	return ""
+}
+
+func (m *ResourceLimits) GetPids() uint64 {
+	if m != nil {
+		return m.Pids
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetIO() []string {
+	if m != nil {
+		return m.IO
+	}
+	return nil
+}
+
+type ResourceUsage struct {
+	MemoryPeak           uint64        `protobuf:"varint,1,opt,name=memory_peak,json=memoryPeak,proto3" json:"memory_peak,omitempty"`
+	CPU                  time.Duration `protobuf:"bytes,2,opt,name=cpu,stdduration" json:"cpu"`
+	PidsPeak             uint64        `protobuf:"varint,3,opt,name=pids_peak,json=pidsPeak,proto3" json:"pids_peak,omitempty"`
+	IOReadBytes          uint64        `protobuf:"varint,4,opt,name=io_read_bytes,json=ioReadBytes,proto3" json:"io_read_bytes,omitempty"`
+	IOWriteBytes         uint64        `protobuf:"varint,5,opt,name=io_write_bytes,json=ioWriteBytes,proto3" json:"io_write_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ResourceUsage) Reset()         { *m = ResourceUsage{} }
+func (m *ResourceUsage) String() string { return proto.CompactTextString(m) }
+func (*ResourceUsage) ProtoMessage()    {}
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{4}
+}
+func (m *ResourceUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceUsage.Unmarshal(m, b)
+}
+func (m *ResourceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceUsage.Marshal(b, m, deterministic)
+}
+func (dst *ResourceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceUsage.Merge(dst, src)
+}
+func (m *ResourceUsage) XXX_Size() int {
+	return xxx_messageInfo_ResourceUsage.Size(m)
+}
+func (m *ResourceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceUsage proto.InternalMessageInfo
+
+func (m *ResourceUsage) GetMemoryPeak() uint64 {
+	if m != nil {
+		return m.MemoryPeak
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetCPU() time.Duration {
+	if m != nil {
+		return m.CPU
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetPidsPeak() uint64 {
+	if m != nil {
+		return m.PidsPeak
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetIOReadBytes() uint64 {
+	if m != nil {
+		return m.IOReadBytes
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetIOWriteBytes() uint64 {
+	if m != nil {
+		return m.IOWriteBytes
+	}
+	return 0
+}
+
+type ExecutionPolicy struct {
+	// user runs the entrypoints as uid[:gid] or user[:group]; the agent user if empty
+	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// namespaces runs the entrypoints in new mount, pid and uts namespaces
+	Namespaces bool `protobuf:"varint,2,opt,name=namespaces,proto3" json:"namespaces,omitempty"`
+	// env is the allowlist of agent environment variables passed to the entrypoints
+	Env []string `protobuf:"bytes,3,rep,name=env" json:"env,omitempty"`
+	// read_only mounts the host read only apart from the writable paths; implies namespaces
+	ReadOnly             bool     `protobuf:"varint,4,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Writable             []string `protobuf:"bytes,5,rep,name=writable" json:"writable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecutionPolicy) Reset()         { *m = ExecutionPolicy{} }
+func (m *ExecutionPolicy) String() string { return proto.CompactTextString(m) }
+func (*ExecutionPolicy) ProtoMessage()    {}
+func (*ExecutionPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{5}
+}
+func (m *ExecutionPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionPolicy.Unmarshal(m, b)
+}
+func (m *ExecutionPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionPolicy.Marshal(b, m, deterministic)
+}
+func (dst *ExecutionPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionPolicy.Merge(dst, src)
+}
+func (m *ExecutionPolicy) XXX_Size() int {
+	return xxx_messageInfo_ExecutionPolicy.Size(m)
+}
+func (m *ExecutionPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionPolicy proto.InternalMessageInfo
+
+func (m *ExecutionPolicy) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ExecutionPolicy) GetNamespaces() bool {
+	if m != nil {
+		return m.Namespaces
+	}
+	return false
+}
+
+func (m *ExecutionPolicy) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *ExecutionPolicy) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *ExecutionPolicy) GetWritable() []string {
+	if m != nil {
+		return m.Writable
+	}
+	return nil
+}
+
+type RetryPolicy struct {
+	// max_attempts is the total number of attempts including the first; 3 if 0
+	MaxAttempts uint32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// backoff is the delay before the first retry and doubles each attempt (e.g. 10s)
+	Backoff string `protobuf:"bytes,2,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	// max_backoff caps the delay between attempts (e.g. 5m)
+	MaxBackoff string `protobuf:"bytes,3,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+	// jitter randomizes each delay by up to the fraction of the delay (0 to 1)
+	Jitter               float64  `protobuf:"fixed64,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetryPolicy) Reset()         { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()    {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{6}
+}
+func (m *RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetryPolicy.Unmarshal(m, b)
+}
+func (m *RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetryPolicy.Marshal(b, m, deterministic)
+}
+func (dst *RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetryPolicy.Merge(dst, src)
+}
+func (m *RetryPolicy) XXX_Size() int {
+	return xxx_messageInfo_RetryPolicy.Size(m)
+}
+func (m *RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetryPolicy proto.InternalMessageInfo
+
+func (m *RetryPolicy) GetMaxAttempts() uint32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBackoff() string {
+	if m != nil {
+		return m.Backoff
+	}
+	return ""
+}
+
+func (m *RetryPolicy) GetMaxBackoff() string {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return ""
+}
+
+func (m *RetryPolicy) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+type Manifest struct {
+	NodeID string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// labels is the deprecated node selection; any matching label selects the node.  use selector instead.
+	Labels     map[string]string `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Assemblies []*Assembly       `protobuf:"bytes,3,rep,name=assemblies" json:"assemblies,omitempty"`
+	// retry is the default retry policy for the assemblies in the manifest
+	Retry *RetryPolicy `protobuf:"bytes,4,opt,name=retry" json:"retry,omitempty"`
+	// policy is the execution policy for the assemblies in the manifest
+	Policy *ExecutionPolicy `protobuf:"bytes,5,opt,name=policy" json:"policy,omitempty"`
+	// variables are available to the parameter templates of the assemblies in the manifest
+	Variables map[string]string `protobuf:"bytes,6,rep,name=variables" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// selector selects the nodes by label (e.g. "env=prod,zone in (a,b),!gpu"); all terms must match
+	// and node_id or node_ids, if set, must also match
+	Selector string `protobuf:"bytes,7,opt,name=selector,proto3" json:"selector,omitempty"`
+	// node_ids are node ids, glob patterns (e.g. web-*) or regular expressions between slashes
+	// (e.g. /^web-[0-9]+$/); any may match
+	NodeIDs []string `protobuf:"bytes,8,rep,name=node_ids,json=nodeIds" json:"node_ids,omitempty"`
+	// exclude are node ids or patterns of nodes the manifest never applies to
+	Exclude              []string `protobuf:"bytes,9,rep,name=exclude" json:"exclude,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Manifest) Reset()         { *m = Manifest{} }
+func (m *Manifest) String() string { return proto.CompactTextString(m) }
+func (*Manifest) ProtoMessage()    {}
+func (*Manifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{7}
+}
+func (m *Manifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Manifest.Unmarshal(m, b)
+}
+func (m *Manifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Manifest.Marshal(b, m, deterministic)
+}
+func (dst *Manifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Manifest.Merge(dst, src)
+}
+func (m *Manifest) XXX_Size() int {
+	return xxx_messageInfo_Manifest.Size(m)
+}
+func (m *Manifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_Manifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Manifest proto.InternalMessageInfo
+
+func (m *Manifest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *Manifest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Manifest) GetAssemblies() []*Assembly {
+	if m != nil {
+		return m.Assemblies
+	}
+	return nil
+}
+
+func (m *Manifest) GetRetry() *RetryPolicy {
+	if m != nil {
+		return m.Retry
+	}
+	return nil
+}
+
+func (m *Manifest) GetPolicy() *ExecutionPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *Manifest) GetVariables() map[string]string {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+func (m *Manifest) GetSelector() string {
+	if m != nil {
+		return m.Selector
+	}
+	return ""
+}
+
+func (m *Manifest) GetNodeIDs() []string {
+	if m != nil {
+		return m.NodeIDs
+	}
+	return nil
+}
+
+func (m *Manifest) GetExclude() []string {
+	if m != nil {
+		return m.Exclude
+	}
+	return nil
+}
+
+type ManifestList struct {
+	Manifests []*Manifest `protobuf:"bytes,1,rep,name=manifests" json:"manifests,omitempty"`
+	Updated   time.Time   `protobuf:"bytes,2,opt,name=updated,stdtime" json:"updated"`
+	// revision is assigned when the manifest list is accepted by the cluster
+	Revision             uint64   `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ManifestList) Reset()         { *m = ManifestList{} }
+func (m *ManifestList) String() string { return proto.CompactTextString(m) }
+func (*ManifestList) ProtoMessage()    {}
+func (*ManifestList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{8}
+}
+func (m *ManifestList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ManifestList.Unmarshal(m, b)
+}
+func (m *ManifestList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ManifestList.Marshal(b, m, deterministic)
+}
+func (dst *ManifestList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ManifestList.Merge(dst, src)
+}
+func (m *ManifestList) XXX_Size() int {
+	return xxx_messageInfo_ManifestList.Size(m)
+}
+func (m *ManifestList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ManifestList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ManifestList proto.InternalMessageInfo
+
+func (m *ManifestList) GetManifests() []*Manifest {
+	if m != nil {
+		return m.Manifests
+	}
+	return nil
+}
+
+func (m *ManifestList) GetUpdated() time.Time {
+	if m != nil {
+		return m.Updated
+	}
+	return time.Time{}
+}
+
+func (m *ManifestList) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type ApplyRequest struct {
+	ManifestList *ManifestList `protobuf:"bytes,1,opt,name=manifest_list,json=manifestList" json:"manifest_list,omitempty"`
+	Force        bool          `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// previous is the manifest list last applied to the node; assemblies
+	// only present in previous are removed
+	Previous             *ManifestList `protobuf:"bytes,3,opt,name=previous" json:"previous,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ApplyRequest) Reset()         { *m = ApplyRequest{} }
+func (m *ApplyRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyRequest) ProtoMessage()    {}
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{9}
+}
+func (m *ApplyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyRequest.Unmarshal(m, b)
+}
+func (m *ApplyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyRequest.Marshal(b, m, deterministic)
+}
+func (dst *ApplyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyRequest.Merge(dst, src)
+}
+func (m *ApplyRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyRequest.Size(m)
+}
+func (m *ApplyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyRequest proto.InternalMessageInfo
+
+func (m *ApplyRequest) GetManifestList() *ManifestList {
+	if m != nil {
+		return m.ManifestList
+	}
+	return nil
+}
+
+func (m *ApplyRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *ApplyRequest) GetPrevious() *ManifestList {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
+type NodesRequest struct {
+	// selector only returns the nodes matching the label selector
+	Selector             string   `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodesRequest) Reset()         { *m = NodesRequest{} }
+func (m *NodesRequest) String() string { return proto.CompactTextString(m) }
+func (*NodesRequest) ProtoMessage()    {}
+func (*NodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{10}
+}
+func (m *NodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodesRequest.Unmarshal(m, b)
+}
+func (m *NodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodesRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodesRequest.Merge(dst, src)
+}
+func (m *NodesRequest) XXX_Size() int {
+	return xxx_messageInfo_NodesRequest.Size(m)
+}
+func (m *NodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodesRequest proto.InternalMessageInfo
+
+func (m *NodesRequest) GetSelector() string {
+	if m != nil {
+		return m.Selector
+	}
+	return ""
+}
+
+type Node struct {
+	ID                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address              string            `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Status               *NodeStatus       `protobuf:"bytes,4,opt,name=status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{11}
+}
+func (m *Node) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node.Unmarshal(m, b)
+}
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+}
+func (dst *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(dst, src)
+}
+func (m *Node) XXX_Size() int {
+	return xxx_messageInfo_Node.Size(m)
+}
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node proto.InternalMessageInfo
+
+func (m *Node) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Node) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Node) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Node) GetStatus() *NodeStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type NodesResponse struct {
+	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodesResponse) Reset()         { *m = NodesResponse{} }
+func (m *NodesResponse) String() string { return proto.CompactTextString(m) }
+func (*NodesResponse) ProtoMessage()    {}
+func (*NodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{12}
+}
+func (m *NodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodesResponse.Unmarshal(m, b)
+}
+func (m *NodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodesResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodesResponse.Merge(dst, src)
+}
+func (m *NodesResponse) XXX_Size() int {
+	return xxx_messageInfo_NodesResponse.Size(m)
+}
+func (m *NodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodesResponse proto.InternalMessageInfo
+
+func (m *NodesResponse) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type StatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{13}
+}
+func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusRequest.Unmarshal(m, b)
+}
+func (m *StatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *StatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusRequest.Merge(dst, src)
+}
+func (m *StatusRequest) XXX_Size() int {
+	return xxx_messageInfo_StatusRequest.Size(m)
+}
+func (m *StatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
+
+type NodeStatus struct {
+	Status      NodeStatus_Status `protobuf:"varint,1,opt,name=status,proto3,enum=io.stellarproject.terra.v1.NodeStatus_Status" json:"status,omitempty"`
+	Description string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Assemblies  []*AssemblyStatus `protobuf:"bytes,3,rep,name=assemblies" json:"assemblies,omitempty"`
+	// labels are the node labels including the collected terra.io/ fact labels
+	Labels               map[string]string `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NodeStatus) Reset()         { *m = NodeStatus{} }
+func (m *NodeStatus) String() string { return proto.CompactTextString(m) }
+func (*NodeStatus) ProtoMessage()    {}
+func (*NodeStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{14}
+}
+func (m *NodeStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeStatus.Unmarshal(m, b)
+}
+func (m *NodeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeStatus.Marshal(b, m, deterministic)
+}
+func (dst *NodeStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeStatus.Merge(dst, src)
+}
+func (m *NodeStatus) XXX_Size() int {
+	return xxx_messageInfo_NodeStatus.Size(m)
+}
+func (m *NodeStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeStatus proto.InternalMessageInfo
+
+func (m *NodeStatus) GetStatus() NodeStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return NodeStatus_UNKNOWN
+}
+
+func (m *NodeStatus) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *NodeStatus) GetAssemblies() []*AssemblyStatus {
+	if m != nil {
+		return m.Assemblies
+	}
+	return nil
+}
+
+func (m *NodeStatus) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type AssemblyStatus struct {
+	Image       string                `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Status      AssemblyStatus_Status `protobuf:"varint,2,opt,name=status,proto3,enum=io.stellarproject.terra.v1.AssemblyStatus_Status" json:"status,omitempty"`
+	Description string                `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// digest is the resolved manifest digest of the applied image
+	Digest string `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	// attempts is the number of failed attempts when the assembly is retried
+	Attempts uint32 `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// next_retry is the time of the next attempt if a retry is scheduled
+	NextRetry *time.Time `protobuf:"bytes,6,opt,name=next_retry,json=nextRetry,stdtime" json:"next_retry,omitempty"`
+	// rule is the manifest rule that selected the assembly for the node
+	Rule                 string   `protobuf:"bytes,7,opt,name=rule,proto3" json:"rule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AssemblyStatus) Reset()         { *m = AssemblyStatus{} }
+func (m *AssemblyStatus) String() string { return proto.CompactTextString(m) }
+func (*AssemblyStatus) ProtoMessage()    {}
+func (*AssemblyStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{15}
+}
+func (m *AssemblyStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AssemblyStatus.Unmarshal(m, b)
+}
+func (m *AssemblyStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AssemblyStatus.Marshal(b, m, deterministic)
+}
+func (dst *AssemblyStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssemblyStatus.Merge(dst, src)
+}
+func (m *AssemblyStatus) XXX_Size() int {
+	return xxx_messageInfo_AssemblyStatus.Size(m)
+}
+func (m *AssemblyStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssemblyStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssemblyStatus proto.InternalMessageInfo
+
+func (m *AssemblyStatus) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *AssemblyStatus) GetStatus() AssemblyStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return AssemblyStatus_UNKNOWN
+}
+
+func (m *AssemblyStatus) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *AssemblyStatus) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *AssemblyStatus) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *AssemblyStatus) GetNextRetry() *time.Time {
+	if m != nil {
+		return m.NextRetry
+	}
+	return nil
+}
+
+func (m *AssemblyStatus) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+type StatusResponse struct {
+	NodeStatus           *NodeStatus `protobuf:"bytes,1,opt,name=node_status,json=nodeStatus" json:"node_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{16}
+}
+func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusResponse.Unmarshal(m, b)
+}
+func (m *StatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *StatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusResponse.Merge(dst, src)
+}
+func (m *StatusResponse) XXX_Size() int {
+	return xxx_messageInfo_StatusResponse.Size(m)
+}
+func (m *StatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusResponse proto.InternalMessageInfo
+
+func (m *StatusResponse) GetNodeStatus() *NodeStatus {
+	if m != nil {
+		return m.NodeStatus
+	}
+	return nil
+}
+
+type UpdateRequest struct {
+	ManifestList *ManifestList `protobuf:"bytes,1,opt,name=manifest_list,json=manifestList" json:"manifest_list,omitempty"`
+	// force applies the manifest list again on the connected node only; use
+	// Rollout to force a cluster update
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{17}
+}
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(dst, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetManifestList() *ManifestList {
+	if m != nil {
+		return m.ManifestList
+	}
+	return nil
+}
+
+func (m *UpdateRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type RolloutRequest struct {
+	ManifestList *ManifestList `protobuf:"bytes,1,opt,name=manifest_list,json=manifestList" json:"manifest_list,omitempty"`
+	Force        bool          `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// batch_size is the number of nodes updated before waiting for the batch to complete
+	BatchSize uint32 `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// max_unavailable is the maximum number of updating or failed nodes
+	MaxUnavailable uint32 `protobuf:"varint,4,opt,name=max_unavailable,json=maxUnavailable,proto3" json:"max_unavailable,omitempty"`
+	// max_failures is the number of failed nodes tolerated before the rollout is halted
+	MaxFailures uint32 `protobuf:"varint,5,opt,name=max_failures,json=maxFailures,proto3" json:"max_failures,omitempty"`
+	// node_timeout is the time to wait for a node to return to OK
+	NodeTimeout time.Duration `protobuf:"bytes,6,opt,name=node_timeout,json=nodeTimeout,stdduration" json:"node_timeout"`
+	// canary updates a subset of nodes before the rest of the cluster
+	Canary               *Canary  `protobuf:"bytes,7,opt,name=canary" json:"canary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RolloutRequest) Reset()         { *m = RolloutRequest{} }
+func (m *RolloutRequest) String() string { return proto.CompactTextString(m) }
+func (*RolloutRequest) ProtoMessage()    {}
+func (*RolloutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{18}
+}
+func (m *RolloutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RolloutRequest.Unmarshal(m, b)
+}
+func (m *RolloutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RolloutRequest.Marshal(b, m, deterministic)
+}
+func (dst *RolloutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RolloutRequest.Merge(dst, src)
+}
+func (m *RolloutRequest) XXX_Size() int {
+	return xxx_messageInfo_RolloutRequest.Size(m)
+}
+func (m *RolloutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RolloutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RolloutRequest proto.InternalMessageInfo
+
+func (m *RolloutRequest) GetManifestList() *ManifestList {
+	if m != nil {
+		return m.ManifestList
+	}
+	return nil
+}
+
+func (m *RolloutRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *RolloutRequest) GetBatchSize() uint32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *RolloutRequest) GetMaxUnavailable() uint32 {
+	if m != nil {
+		return m.MaxUnavailable
+	}
+	return 0
+}
+
+func (m *RolloutRequest) GetMaxFailures() uint32 {
+	if m != nil {
+		return m.MaxFailures
+	}
+	return 0
+}
+
+func (m *RolloutRequest) GetNodeTimeout() time.Duration {
+	if m != nil {
+		return m.NodeTimeout
+	}
+	return 0
+}
+
+func (m *RolloutRequest) GetCanary() *Canary {
+	if m != nil {
+		return m.Canary
+	}
+	return nil
+}
+
+type Canary struct {
+	// labels is the deprecated canary selection; all labels must match.  use selector instead.
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// percent of the cluster used as canary nodes when no labels are specified
+	Percent uint32 `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	// bake_time is the time the canary nodes must remain healthy before promotion
+	BakeTime time.Duration `protobuf:"bytes,3,opt,name=bake_time,json=bakeTime,stdduration" json:"bake_time"`
+	// health_interval is the time between health checks while baking
+	HealthInterval time.Duration `protobuf:"bytes,4,opt,name=health_interval,json=healthInterval,stdduration" json:"health_interval"`
+	// health is an optional assembly whose health executable is run on each canary
+	Health *Assembly `protobuf:"bytes,5,opt,name=health" json:"health,omitempty"`
+	// selector selects the canary nodes by label
+	Selector             string   `protobuf:"bytes,6,opt,name=selector,proto3" json:"selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Canary) Reset()         { *m = Canary{} }
+func (m *Canary) String() string { return proto.CompactTextString(m) }
+func (*Canary) ProtoMessage()    {}
+func (*Canary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{19}
+}
+func (m *Canary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Canary.Unmarshal(m, b)
+}
+func (m *Canary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Canary.Marshal(b, m, deterministic)
+}
+func (dst *Canary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Canary.Merge(dst, src)
+}
+func (m *Canary) XXX_Size() int {
+	return xxx_messageInfo_Canary.Size(m)
+}
+func (m *Canary) XXX_DiscardUnknown() {
+	xxx_messageInfo_Canary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Canary proto.InternalMessageInfo
+
+func (m *Canary) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Canary) GetPercent() uint32 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *Canary) GetBakeTime() time.Duration {
+	if m != nil {
+		return m.BakeTime
+	}
+	return 0
+}
+
+func (m *Canary) GetHealthInterval() time.Duration {
+	if m != nil {
+		return m.HealthInterval
+	}
+	return 0
+}
+
+func (m *Canary) GetHealth() *Assembly {
+	if m != nil {
+		return m.Health
+	}
+	return nil
+}
+
+func (m *Canary) GetSelector() string {
+	if m != nil {
+		return m.Selector
+	}
+	return ""
+}
+
+type RolloutEvent struct {
+	Type                 RolloutEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=io.stellarproject.terra.v1.RolloutEvent_Type" json:"type,omitempty"`
+	Batch                uint32            `protobuf:"varint,2,opt,name=batch,proto3" json:"batch,omitempty"`
+	NodeID               string            `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Description          string            `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Timestamp            time.Time         `protobuf:"bytes,5,opt,name=timestamp,stdtime" json:"timestamp"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RolloutEvent) Reset()         { *m = RolloutEvent{} }
+func (m *RolloutEvent) String() string { return proto.CompactTextString(m) }
+func (*RolloutEvent) ProtoMessage()    {}
+func (*RolloutEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{20}
+}
+func (m *RolloutEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RolloutEvent.Unmarshal(m, b)
+}
+func (m *RolloutEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RolloutEvent.Marshal(b, m, deterministic)
+}
+func (dst *RolloutEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RolloutEvent.Merge(dst, src)
+}
+func (m *RolloutEvent) XXX_Size() int {
+	return xxx_messageInfo_RolloutEvent.Size(m)
+}
+func (m *RolloutEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RolloutEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RolloutEvent proto.InternalMessageInfo
+
+func (m *RolloutEvent) GetType() RolloutEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return RolloutEvent_UNKNOWN
+}
+
+func (m *RolloutEvent) GetBatch() uint32 {
+	if m != nil {
+		return m.Batch
+	}
+	return 0
+}
+
+func (m *RolloutEvent) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *RolloutEvent) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *RolloutEvent) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+type HealthRequest struct {
+	Assembly             *Assembly `protobuf:"bytes,1,opt,name=assembly" json:"assembly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
+func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthRequest) ProtoMessage()    {}
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{21}
+}
+func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
+}
+func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
+}
+func (dst *HealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthRequest.Merge(dst, src)
+}
+func (m *HealthRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthRequest.Size(m)
+}
+func (m *HealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
+
+func (m *HealthRequest) GetAssembly() *Assembly {
+	if m != nil {
+		return m.Assembly
+	}
+	return nil
+}
+
+type HealthResponse struct {
+	Healthy              bool     `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Output               string   `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
+func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
+func (*HealthResponse) ProtoMessage()    {}
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{22}
+}
+func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthResponse.Unmarshal(m, b)
+}
+func (m *HealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthResponse.Marshal(b, m, deterministic)
+}
+func (dst *HealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthResponse.Merge(dst, src)
+}
+func (m *HealthResponse) XXX_Size() int {
+	return xxx_messageInfo_HealthResponse.Size(m)
+}
+func (m *HealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthResponse proto.InternalMessageInfo
+
+func (m *HealthResponse) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *HealthResponse) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+type HistoryRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistoryRequest) Reset()         { *m = HistoryRequest{} }
+func (m *HistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HistoryRequest) ProtoMessage()    {}
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{23}
+}
+func (m *HistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryRequest.Unmarshal(m, b)
+}
+func (m *HistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *HistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryRequest.Merge(dst, src)
+}
+func (m *HistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_HistoryRequest.Size(m)
+}
+func (m *HistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryRequest proto.InternalMessageInfo
+
+type HistoryResponse struct {
+	// revisions are the stored manifest lists ordered by revision
+	Revisions            []*ManifestList `protobuf:"bytes,1,rep,name=revisions" json:"revisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *HistoryResponse) Reset()         { *m = HistoryResponse{} }
+func (m *HistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*HistoryResponse) ProtoMessage()    {}
+func (*HistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{24}
+}
+func (m *HistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryResponse.Unmarshal(m, b)
+}
+func (m *HistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryResponse.Marshal(b, m, deterministic)
+}
+func (dst *HistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryResponse.Merge(dst, src)
+}
+func (m *HistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_HistoryResponse.Size(m)
+}
+func (m *HistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryResponse proto.InternalMessageInfo
+
+func (m *HistoryResponse) GetRevisions() []*ManifestList {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type PlanRequest struct {
+	ManifestList *ManifestList `protobuf:"bytes,1,opt,name=manifest_list,json=manifestList" json:"manifest_list,omitempty"`
+	Force        bool          `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// cluster plans the manifest list on all nodes in the cluster
+	Cluster              bool     `protobuf:"varint,3,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlanRequest) Reset()         { *m = PlanRequest{} }
+func (m *PlanRequest) String() string { return proto.CompactTextString(m) }
+func (*PlanRequest) ProtoMessage()    {}
+func (*PlanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{25}
+}
+func (m *PlanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanRequest.Unmarshal(m, b)
+}
+func (m *PlanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanRequest.Marshal(b, m, deterministic)
+}
+func (dst *PlanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanRequest.Merge(dst, src)
+}
+func (m *PlanRequest) XXX_Size() int {
+	return xxx_messageInfo_PlanRequest.Size(m)
+}
+func (m *PlanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanRequest proto.InternalMessageInfo
+
+func (m *PlanRequest) GetManifestList() *ManifestList {
+	if m != nil {
+		return m.ManifestList
+	}
+	return nil
+}
+
+func (m *PlanRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *PlanRequest) GetCluster() bool {
+	if m != nil {
+		return m.Cluster
+	}
+	return false
+}
+
+type PlanResponse struct {
+	Nodes                []*NodePlan `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PlanResponse) Reset()         { *m = PlanResponse{} }
+func (m *PlanResponse) String() string { return proto.CompactTextString(m) }
+func (*PlanResponse) ProtoMessage()    {}
+func (*PlanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{26}
+}
+func (m *PlanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanResponse.Unmarshal(m, b)
+}
+func (m *PlanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanResponse.Marshal(b, m, deterministic)
+}
+func (dst *PlanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanResponse.Merge(dst, src)
+}
+func (m *PlanResponse) XXX_Size() int {
+	return xxx_messageInfo_PlanResponse.Size(m)
+}
+func (m *PlanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanResponse proto.InternalMessageInfo
+
+func (m *PlanResponse) GetNodes() []*NodePlan {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type NodePlan struct {
+	NodeID     string             `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Assemblies []*PlannedAssembly `protobuf:"bytes,2,rep,name=assemblies" json:"assemblies,omitempty"`
+	// error is set if the node could not plan the manifest list
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodePlan) Reset()         { *m = NodePlan{} }
+func (m *NodePlan) String() string { return proto.CompactTextString(m) }
+func (*NodePlan) ProtoMessage()    {}
+func (*NodePlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{27}
+}
+func (m *NodePlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodePlan.Unmarshal(m, b)
+}
+func (m *NodePlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodePlan.Marshal(b, m, deterministic)
+}
+func (dst *NodePlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodePlan.Merge(dst, src)
+}
+func (m *NodePlan) XXX_Size() int {
+	return xxx_messageInfo_NodePlan.Size(m)
+}
+func (m *NodePlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodePlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodePlan proto.InternalMessageInfo
+
+func (m *NodePlan) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *NodePlan) GetAssemblies() []*PlannedAssembly {
+	if m != nil {
+		return m.Assemblies
+	}
+	return nil
+}
+
+func (m *NodePlan) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type PlannedAssembly struct {
+	Image  string                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Action PlannedAssembly_Action `protobuf:"varint,2,opt,name=action,proto3,enum=io.stellarproject.terra.v1.PlannedAssembly_Action" json:"action,omitempty"`
+	// digest is the resolved digest that would be applied
+	Digest string `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	// applied_digest is the digest currently applied on the node
+	AppliedDigest string `protobuf:"bytes,4,opt,name=applied_digest,json=appliedDigest,proto3" json:"applied_digest,omitempty"`
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	// rule is the manifest rule that selected the assembly for the node
+	Rule                 string   `protobuf:"bytes,6,opt,name=rule,proto3" json:"rule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlannedAssembly) Reset()         { *m = PlannedAssembly{} }
+func (m *PlannedAssembly) String() string { return proto.CompactTextString(m) }
+func (*PlannedAssembly) ProtoMessage()    {}
+func (*PlannedAssembly) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{28}
+}
+func (m *PlannedAssembly) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlannedAssembly.Unmarshal(m, b)
+}
+func (m *PlannedAssembly) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlannedAssembly.Marshal(b, m, deterministic)
+}
+func (dst *PlannedAssembly) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlannedAssembly.Merge(dst, src)
+}
+func (m *PlannedAssembly) XXX_Size() int {
+	return xxx_messageInfo_PlannedAssembly.Size(m)
+}
+func (m *PlannedAssembly) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlannedAssembly.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlannedAssembly proto.InternalMessageInfo
+
+func (m *PlannedAssembly) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *PlannedAssembly) GetAction() PlannedAssembly_Action {
+	if m != nil {
+		return m.Action
+	}
+	return PlannedAssembly_UNKNOWN
+}
+
+func (m *PlannedAssembly) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *PlannedAssembly) GetAppliedDigest() string {
+	if m != nil {
+		return m.AppliedDigest
+	}
+	return ""
+}
+
+func (m *PlannedAssembly) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *PlannedAssembly) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+type LogsRequest struct {
+	// follow streams new output until the request is cancelled
+	Follow bool `protobuf:"varint,1,opt,name=follow,proto3" json:"follow,omitempty"`
+	// image only returns output for the assembly image
+	Image string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	// tail is the number of recent lines per execution to return; all if 0
+	Tail uint32 `protobuf:"varint,3,opt,name=tail,proto3" json:"tail,omitempty"`
+	// cluster streams the output of all nodes in the cluster
+	Cluster              bool     `protobuf:"varint,4,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogsRequest) Reset()         { *m = LogsRequest{} }
+func (m *LogsRequest) String() string { return proto.CompactTextString(m) }
+func (*LogsRequest) ProtoMessage()    {}
+func (*LogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{29}
+}
+func (m *LogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogsRequest.Unmarshal(m, b)
+}
+func (m *LogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogsRequest.Marshal(b, m, deterministic)
+}
+func (dst *LogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogsRequest.Merge(dst, src)
+}
+func (m *LogsRequest) XXX_Size() int {
+	return xxx_messageInfo_LogsRequest.Size(m)
+}
+func (m *LogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogsRequest proto.InternalMessageInfo
+
+func (m *LogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *LogsRequest) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *LogsRequest) GetTail() uint32 {
+	if m != nil {
+		return m.Tail
+	}
+	return 0
+}
+
+func (m *LogsRequest) GetCluster() bool {
+	if m != nil {
+		return m.Cluster
+	}
+	return false
+}
+
+type LogEntry struct {
+	NodeID     string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Image      string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Entrypoint string `protobuf:"bytes,3,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	// stream is stdout or stderr
+	Stream    string    `protobuf:"bytes,4,opt,name=stream,proto3" json:"stream,omitempty"`
+	Line      string    `protobuf:"bytes,5,opt,name=line,proto3" json:"line,omitempty"`
+	Timestamp time.Time `protobuf:"bytes,6,opt,name=timestamp,stdtime" json:"timestamp"`
+	// execution identifies the assembly execution on the node
+	Execution            uint64   `protobuf:"varint,7,opt,name=execution,proto3" json:"execution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{30}
+}
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (dst *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(dst, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *LogEntry) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *LogEntry) GetEntrypoint() string {
+	if m != nil {
+		return m.Entrypoint
+	}
+	return ""
+}
+
+func (m *LogEntry) GetStream() string {
+	if m != nil {
+		return m.Stream
+	}
+	return ""
+}
+
+func (m *LogEntry) GetLine() string {
+	if m != nil {
+		return m.Line
+	}
+	return ""
+}
+
+func (m *LogEntry) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *LogEntry) GetExecution() uint64 {
+	if m != nil {
+		return m.Execution
+	}
+	return 0
+}
+
+type ExecutionsRequest struct {
+	// node_id only returns executions for the node; all nodes if empty
+	NodeID string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// image only returns executions for the assembly image
+	Image string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	// limit is the number of most recent executions per node to return; all if 0
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecutionsRequest) Reset()         { *m = ExecutionsRequest{} }
+func (m *ExecutionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecutionsRequest) ProtoMessage()    {}
+func (*ExecutionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{31}
+}
+func (m *ExecutionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionsRequest.Unmarshal(m, b)
+}
+func (m *ExecutionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExecutionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionsRequest.Merge(dst, src)
+}
+func (m *ExecutionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecutionsRequest.Size(m)
+}
+func (m *ExecutionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionsRequest proto.InternalMessageInfo
+
+func (m *ExecutionsRequest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *ExecutionsRequest) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *ExecutionsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ExecutionsResponse struct {
+	Executions           []*Execution `protobuf:"bytes,1,rep,name=executions" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ExecutionsResponse) Reset()         { *m = ExecutionsResponse{} }
+func (m *ExecutionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecutionsResponse) ProtoMessage()    {}
+func (*ExecutionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{32}
+}
+func (m *ExecutionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionsResponse.Unmarshal(m, b)
+}
+func (m *ExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ExecutionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionsResponse.Merge(dst, src)
+}
+func (m *ExecutionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecutionsResponse.Size(m)
+}
+func (m *ExecutionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionsResponse proto.InternalMessageInfo
+
+func (m *ExecutionsResponse) GetExecutions() []*Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type Execution struct {
+	ID             uint64            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NodeID         string            `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Image          string            `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Entrypoint     string            `protobuf:"bytes,4,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	Digest         string            `protobuf:"bytes,5,opt,name=digest,proto3" json:"digest,omitempty"`
+	ParametersHash string            `protobuf:"bytes,6,opt,name=parameters_hash,json=parametersHash,proto3" json:"parameters_hash,omitempty"`
+	Trigger        Execution_Trigger `protobuf:"varint,7,opt,name=trigger,proto3,enum=io.stellarproject.terra.v1.Execution_Trigger" json:"trigger,omitempty"`
+	Result         Execution_Result  `protobuf:"varint,8,opt,name=result,proto3,enum=io.stellarproject.terra.v1.Execution_Result" json:"result,omitempty"`
+	Started        time.Time         `protobuf:"bytes,9,opt,name=started,stdtime" json:"started"`
+	Finished       time.Time         `protobuf:"bytes,10,opt,name=finished,stdtime" json:"finished"`
+	Duration       time.Duration     `protobuf:"bytes,11,opt,name=duration,stdduration" json:"duration"`
+	// exit_code is the entrypoint exit code or -1 if the entrypoint did not run
+	ExitCode int32 `protobuf:"varint,12,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// output is the combined output truncated to the most recent bytes
+	Output string `protobuf:"bytes,13,opt,name=output,proto3" json:"output,omitempty"`
+	Error  string `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
+	// usage is the peak resource usage of the entrypoint when run in a cgroup
+	Usage                *ResourceUsage `protobuf:"bytes,15,opt,name=usage" json:"usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Execution) Reset()         { *m = Execution{} }
+func (m *Execution) String() string { return proto.CompactTextString(m) }
+func (*Execution) ProtoMessage()    {}
+func (*Execution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{33}
+}
+func (m *Execution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Execution.Unmarshal(m, b)
+}
+func (m *Execution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Execution.Marshal(b, m, deterministic)
+}
+func (dst *Execution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Execution.Merge(dst, src)
+}
+func (m *Execution) XXX_Size() int {
+	return xxx_messageInfo_Execution.Size(m)
+}
+func (m *Execution) XXX_DiscardUnknown() {
+	xxx_messageInfo_Execution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Execution proto.InternalMessageInfo
+
+func (m *Execution) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *Execution) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *Execution) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Execution) GetEntrypoint() string {
+	if m != nil {
+		return m.Entrypoint
+	}
+	return ""
+}
+
+func (m *Execution) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *Execution) GetParametersHash() string {
+	if m != nil {
+		return m.ParametersHash
+	}
+	return ""
+}
+
+func (m *Execution) GetTrigger() Execution_Trigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return Execution_UNKNOWN
+}
+
+func (m *Execution) GetResult() Execution_Result {
+	if m != nil {
+		return m.Result
+	}
+	return Execution_NONE
+}
+
+func (m *Execution) GetStarted() time.Time {
+	if m != nil {
+		return m.Started
+	}
+	return time.Time{}
+}
+
+func (m *Execution) GetFinished() time.Time {
+	if m != nil {
+		return m.Finished
+	}
+	return time.Time{}
+}
+
+func (m *Execution) GetDuration() time.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *Execution) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *Execution) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *Execution) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Execution) GetUsage() *ResourceUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+type CancelRequest struct {
+	// node_id is the node to cancel; the connected node if empty
+	NodeID               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelRequest) Reset()         { *m = CancelRequest{} }
+func (m *CancelRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelRequest) ProtoMessage()    {}
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{34}
+}
+func (m *CancelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelRequest.Unmarshal(m, b)
+}
+func (m *CancelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelRequest.Marshal(b, m, deterministic)
+}
+func (dst *CancelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelRequest.Merge(dst, src)
+}
+func (m *CancelRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelRequest.Size(m)
+}
+func (m *CancelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelRequest proto.InternalMessageInfo
+
+func (m *CancelRequest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+type Secret struct {
+	Name    string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Updated time.Time `protobuf:"bytes,2,opt,name=updated,stdtime" json:"updated"`
+	// deleted secrets are kept so the removal is replicated
+	Deleted              bool     `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Secret) Reset()         { *m = Secret{} }
+func (m *Secret) String() string { return proto.CompactTextString(m) }
+func (*Secret) ProtoMessage()    {}
+func (*Secret) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{35}
+}
+func (m *Secret) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Secret.Unmarshal(m, b)
+}
+func (m *Secret) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Secret.Marshal(b, m, deterministic)
+}
+func (dst *Secret) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Secret.Merge(dst, src)
+}
+func (m *Secret) XXX_Size() int {
+	return xxx_messageInfo_Secret.Size(m)
+}
+func (m *Secret) XXX_DiscardUnknown() {
+	xxx_messageInfo_Secret.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Secret proto.InternalMessageInfo
+
+func (m *Secret) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Secret) GetUpdated() time.Time {
+	if m != nil {
+		return m.Updated
+	}
+	return time.Time{}
+}
+
+func (m *Secret) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+type SetSecretRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetSecretRequest) Reset()         { *m = SetSecretRequest{} }
+func (m *SetSecretRequest) String() string { return proto.CompactTextString(m) }
+func (*SetSecretRequest) ProtoMessage()    {}
+func (*SetSecretRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{36}
+}
+func (m *SetSecretRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetSecretRequest.Unmarshal(m, b)
+}
+func (m *SetSecretRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetSecretRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetSecretRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSecretRequest.Merge(dst, src)
+}
+func (m *SetSecretRequest) XXX_Size() int {
+	return xxx_messageInfo_SetSecretRequest.Size(m)
+}
+func (m *SetSecretRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSecretRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSecretRequest proto.InternalMessageInfo
+
+func (m *SetSecretRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetSecretRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type GetSecretRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSecretRequest) Reset()         { *m = GetSecretRequest{} }
+func (m *GetSecretRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSecretRequest) ProtoMessage()    {}
+func (*GetSecretRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{37}
+}
+func (m *GetSecretRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSecretRequest.Unmarshal(m, b)
+}
+func (m *GetSecretRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSecretRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetSecretRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSecretRequest.Merge(dst, src)
+}
+func (m *GetSecretRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSecretRequest.Size(m)
+}
+func (m *GetSecretRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSecretRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSecretRequest proto.InternalMessageInfo
+
+func (m *GetSecretRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetSecretResponse struct {
+	Secret               *Secret  `protobuf:"bytes,1,opt,name=secret" json:"secret,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSecretResponse) Reset()         { *m = GetSecretResponse{} }
+func (m *GetSecretResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSecretResponse) ProtoMessage()    {}
+func (*GetSecretResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{38}
+}
+func (m *GetSecretResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSecretResponse.Unmarshal(m, b)
+}
+func (m *GetSecretResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSecretResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetSecretResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSecretResponse.Merge(dst, src)
+}
+func (m *GetSecretResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSecretResponse.Size(m)
+}
+func (m *GetSecretResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSecretResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSecretResponse proto.InternalMessageInfo
+
+func (m *GetSecretResponse) GetSecret() *Secret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+func (m *GetSecretResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type RemoveSecretRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveSecretRequest) Reset()         { *m = RemoveSecretRequest{} }
+func (m *RemoveSecretRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveSecretRequest) ProtoMessage()    {}
+func (*RemoveSecretRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{39}
+}
+func (m *RemoveSecretRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveSecretRequest.Unmarshal(m, b)
+}
+func (m *RemoveSecretRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveSecretRequest.Marshal(b, m, deterministic)
+}
+func (dst *RemoveSecretRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveSecretRequest.Merge(dst, src)
+}
+func (m *RemoveSecretRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveSecretRequest.Size(m)
+}
+func (m *RemoveSecretRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveSecretRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveSecretRequest proto.InternalMessageInfo
+
+func (m *RemoveSecretRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SecretsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SecretsRequest) Reset()         { *m = SecretsRequest{} }
+func (m *SecretsRequest) String() string { return proto.CompactTextString(m) }
+func (*SecretsRequest) ProtoMessage()    {}
+func (*SecretsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{40}
+}
+func (m *SecretsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecretsRequest.Unmarshal(m, b)
+}
+func (m *SecretsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecretsRequest.Marshal(b, m, deterministic)
+}
+func (dst *SecretsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecretsRequest.Merge(dst, src)
+}
+func (m *SecretsRequest) XXX_Size() int {
+	return xxx_messageInfo_SecretsRequest.Size(m)
+}
+func (m *SecretsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecretsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecretsRequest proto.InternalMessageInfo
+
+type SecretsResponse struct {
+	Secrets              []*Secret `protobuf:"bytes,1,rep,name=secrets" json:"secrets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SecretsResponse) Reset()         { *m = SecretsResponse{} }
+func (m *SecretsResponse) String() string { return proto.CompactTextString(m) }
+func (*SecretsResponse) ProtoMessage()    {}
+func (*SecretsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{41}
+}
+func (m *SecretsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecretsResponse.Unmarshal(m, b)
+}
+func (m *SecretsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecretsResponse.Marshal(b, m, deterministic)
+}
+func (dst *SecretsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecretsResponse.Merge(dst, src)
+}
+func (m *SecretsResponse) XXX_Size() int {
+	return xxx_messageInfo_SecretsResponse.Size(m)
+}
+func (m *SecretsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecretsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecretsResponse proto.InternalMessageInfo
+
+func (m *SecretsResponse) GetSecrets() []*Secret {
+	if m != nil {
+		return m.Secrets
+	}
+	return nil
+}
+
+// SealedSecret is a stored secret as replicated between nodes.  the value is
+// never sent in plaintext; the ciphertext can only be opened with the cluster key.
+type SealedSecret struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Nonce []byte `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// ciphertext is the value sealed with the cluster key; the name, updated
+	// and deleted fields are authenticated with the value
+	Ciphertext           []byte    `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	Updated              time.Time `protobuf:"bytes,4,opt,name=updated,stdtime" json:"updated"`
+	Deleted              bool      `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SealedSecret) Reset()         { *m = SealedSecret{} }
+func (m *SealedSecret) String() string { return proto.CompactTextString(m) }
+func (*SealedSecret) ProtoMessage()    {}
+func (*SealedSecret) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{42}
+}
+func (m *SealedSecret) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SealedSecret.Unmarshal(m, b)
+}
+func (m *SealedSecret) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SealedSecret.Marshal(b, m, deterministic)
+}
+func (dst *SealedSecret) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SealedSecret.Merge(dst, src)
+}
+func (m *SealedSecret) XXX_Size() int {
+	return xxx_messageInfo_SealedSecret.Size(m)
+}
+func (m *SealedSecret) XXX_DiscardUnknown() {
+	xxx_messageInfo_SealedSecret.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SealedSecret proto.InternalMessageInfo
+
+func (m *SealedSecret) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SealedSecret) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *SealedSecret) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+func (m *SealedSecret) GetUpdated() time.Time {
+	if m != nil {
+		return m.Updated
+	}
+	return time.Time{}
+}
+
+func (m *SealedSecret) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+type ReplicateSecretRequest struct {
+	Secret               *SealedSecret `protobuf:"bytes,1,opt,name=secret" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ReplicateSecretRequest) Reset()         { *m = ReplicateSecretRequest{} }
+func (m *ReplicateSecretRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplicateSecretRequest) ProtoMessage()    {}
+func (*ReplicateSecretRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{43}
+}
+func (m *ReplicateSecretRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicateSecretRequest.Unmarshal(m, b)
+}
+func (m *ReplicateSecretRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicateSecretRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReplicateSecretRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicateSecretRequest.Merge(dst, src)
+}
+func (m *ReplicateSecretRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplicateSecretRequest.Size(m)
+}
+func (m *ReplicateSecretRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicateSecretRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicateSecretRequest proto.InternalMessageInfo
+
+func (m *ReplicateSecretRequest) GetSecret() *SealedSecret {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+type SealedSecretsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SealedSecretsRequest) Reset()         { *m = SealedSecretsRequest{} }
+func (m *SealedSecretsRequest) String() string { return proto.CompactTextString(m) }
+func (*SealedSecretsRequest) ProtoMessage()    {}
+func (*SealedSecretsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{44}
+}
+func (m *SealedSecretsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SealedSecretsRequest.Unmarshal(m, b)
+}
+func (m *SealedSecretsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SealedSecretsRequest.Marshal(b, m, deterministic)
+}
+func (dst *SealedSecretsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SealedSecretsRequest.Merge(dst, src)
+}
+func (m *SealedSecretsRequest) XXX_Size() int {
+	return xxx_messageInfo_SealedSecretsRequest.Size(m)
+}
+func (m *SealedSecretsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SealedSecretsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SealedSecretsRequest proto.InternalMessageInfo
+
+type SealedSecretsResponse struct {
+	Secrets              []*SealedSecret `protobuf:"bytes,1,rep,name=secrets" json:"secrets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SealedSecretsResponse) Reset()         { *m = SealedSecretsResponse{} }
+func (m *SealedSecretsResponse) String() string { return proto.CompactTextString(m) }
+func (*SealedSecretsResponse) ProtoMessage()    {}
+func (*SealedSecretsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{45}
+}
+func (m *SealedSecretsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SealedSecretsResponse.Unmarshal(m, b)
+}
+func (m *SealedSecretsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SealedSecretsResponse.Marshal(b, m, deterministic)
+}
+func (dst *SealedSecretsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SealedSecretsResponse.Merge(dst, src)
+}
+func (m *SealedSecretsResponse) XXX_Size() int {
+	return xxx_messageInfo_SealedSecretsResponse.Size(m)
+}
+func (m *SealedSecretsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SealedSecretsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SealedSecretsResponse proto.InternalMessageInfo
+
+func (m *SealedSecretsResponse) GetSecrets() []*SealedSecret {
+	if m != nil {
+		return m.Secrets
+	}
+	return nil
+}
+
+type ContentRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContentRequest) Reset()         { *m = ContentRequest{} }
+func (m *ContentRequest) String() string { return proto.CompactTextString(m) }
+func (*ContentRequest) ProtoMessage()    {}
+func (*ContentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{46}
+}
+func (m *ContentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentRequest.Unmarshal(m, b)
+}
+func (m *ContentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentRequest.Marshal(b, m, deterministic)
+}
+func (dst *ContentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentRequest.Merge(dst, src)
+}
+func (m *ContentRequest) XXX_Size() int {
+	return xxx_messageInfo_ContentRequest.Size(m)
+}
+func (m *ContentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContentRequest proto.InternalMessageInfo
+
+type Blob struct {
+	Digest    string    `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Size_     int64     `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	MediaType string    `protobuf:"bytes,3,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Created   time.Time `protobuf:"bytes,4,opt,name=created,stdtime" json:"created"`
+	// images are the fetched image references that include the blob
+	Images []string `protobuf:"bytes,5,rep,name=images" json:"images,omitempty"`
+	// referenced blobs are kept by garbage collection
+	Referenced           bool     `protobuf:"varint,6,opt,name=referenced,proto3" json:"referenced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Blob) Reset()         { *m = Blob{} }
+func (m *Blob) String() string { return proto.CompactTextString(m) }
+func (*Blob) ProtoMessage()    {}
+func (*Blob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{47}
+}
+func (m *Blob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Blob.Unmarshal(m, b)
+}
+func (m *Blob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Blob.Marshal(b, m, deterministic)
+}
+func (dst *Blob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Blob.Merge(dst, src)
+}
+func (m *Blob) XXX_Size() int {
+	return xxx_messageInfo_Blob.Size(m)
+}
+func (m *Blob) XXX_DiscardUnknown() {
+	xxx_messageInfo_Blob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Blob proto.InternalMessageInfo
+
+func (m *Blob) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *Blob) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *Blob) GetMediaType() string {
+	if m != nil {
+		return m.MediaType
+	}
+	return ""
+}
+
+func (m *Blob) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
+	}
+	return time.Time{}
+}
+
+func (m *Blob) GetImages() []string {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+func (m *Blob) GetReferenced() bool {
+	if m != nil {
+		return m.Referenced
+	}
+	return false
+}
+
+type Download struct {
+	Ref                  string    `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Offset               int64     `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Total                int64     `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Updated              time.Time `protobuf:"bytes,4,opt,name=updated,stdtime" json:"updated"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Download) Reset()         { *m = Download{} }
+func (m *Download) String() string { return proto.CompactTextString(m) }
+func (*Download) ProtoMessage()    {}
+func (*Download) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{48}
+}
+func (m *Download) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Download.Unmarshal(m, b)
+}
+func (m *Download) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Download.Marshal(b, m, deterministic)
+}
+func (dst *Download) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Download.Merge(dst, src)
+}
+func (m *Download) XXX_Size() int {
+	return xxx_messageInfo_Download.Size(m)
+}
+func (m *Download) XXX_DiscardUnknown() {
+	xxx_messageInfo_Download.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Download proto.InternalMessageInfo
+
+func (m *Download) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *Download) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *Download) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Download) GetUpdated() time.Time {
+	if m != nil {
+		return m.Updated
+	}
+	return time.Time{}
+}
+
+type ContentResponse struct {
+	Blobs []*Blob `protobuf:"bytes,1,rep,name=blobs" json:"blobs,omitempty"`
+	// downloads are the partial downloads that are resumed on the next fetch
+	Downloads            []*Download `protobuf:"bytes,2,rep,name=downloads" json:"downloads,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ContentResponse) Reset()         { *m = ContentResponse{} }
+func (m *ContentResponse) String() string { return proto.CompactTextString(m) }
+func (*ContentResponse) ProtoMessage()    {}
+func (*ContentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{49}
+}
+func (m *ContentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentResponse.Unmarshal(m, b)
+}
+func (m *ContentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentResponse.Marshal(b, m, deterministic)
+}
+func (dst *ContentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentResponse.Merge(dst, src)
+}
+func (m *ContentResponse) XXX_Size() int {
+	return xxx_messageInfo_ContentResponse.Size(m)
+}
+func (m *ContentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContentResponse proto.InternalMessageInfo
+
+func (m *ContentResponse) GetBlobs() []*Blob {
+	if m != nil {
+		return m.Blobs
+	}
+	return nil
+}
+
+func (m *ContentResponse) GetDownloads() []*Download {
+	if m != nil {
+		return m.Downloads
+	}
+	return nil
+}
+
+type PruneContentRequest struct {
+	// dry_run returns the blobs that would be removed
+	DryRun               bool     `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneContentRequest) Reset()         { *m = PruneContentRequest{} }
+func (m *PruneContentRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneContentRequest) ProtoMessage()    {}
+func (*PruneContentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{50}
+}
+func (m *PruneContentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneContentRequest.Unmarshal(m, b)
+}
+func (m *PruneContentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneContentRequest.Marshal(b, m, deterministic)
+}
+func (dst *PruneContentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneContentRequest.Merge(dst, src)
+}
+func (m *PruneContentRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneContentRequest.Size(m)
+}
+func (m *PruneContentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneContentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneContentRequest proto.InternalMessageInfo
+
+func (m *PruneContentRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type PruneContentResponse struct {
+	Removed []*Blob `protobuf:"bytes,1,rep,name=removed" json:"removed,omitempty"`
+	// aborted are the refs of the removed stale downloads
+	Aborted              []string `protobuf:"bytes,2,rep,name=aborted" json:"aborted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneContentResponse) Reset()         { *m = PruneContentResponse{} }
+func (m *PruneContentResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneContentResponse) ProtoMessage()    {}
+func (*PruneContentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{51}
+}
+func (m *PruneContentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneContentResponse.Unmarshal(m, b)
+}
+func (m *PruneContentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneContentResponse.Marshal(b, m, deterministic)
+}
+func (dst *PruneContentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneContentResponse.Merge(dst, src)
+}
+func (m *PruneContentResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneContentResponse.Size(m)
+}
+func (m *PruneContentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneContentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneContentResponse proto.InternalMessageInfo
+
+func (m *PruneContentResponse) GetRemoved() []*Blob {
+	if m != nil {
+		return m.Removed
+	}
+	return nil
+}
+
+func (m *PruneContentResponse) GetAborted() []string {
+	if m != nil {
+		return m.Aborted
+	}
+	return nil
+}
+
+type ContentImagesRequest struct {
+	Digests              []string `protobuf:"bytes,1,rep,name=digests" json:"digests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContentImagesRequest) Reset()         { *m = ContentImagesRequest{} }
+func (m *ContentImagesRequest) String() string { return proto.CompactTextString(m) }
+func (*ContentImagesRequest) ProtoMessage()    {}
+func (*ContentImagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{52}
+}
+func (m *ContentImagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentImagesRequest.Unmarshal(m, b)
+}
+func (m *ContentImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentImagesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ContentImagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentImagesRequest.Merge(dst, src)
+}
+func (m *ContentImagesRequest) XXX_Size() int {
+	return xxx_messageInfo_ContentImagesRequest.Size(m)
+}
+func (m *ContentImagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentImagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContentImagesRequest proto.InternalMessageInfo
+
+func (m *ContentImagesRequest) GetDigests() []string {
+	if m != nil {
+		return m.Digests
+	}
+	return nil
+}
+
+type ContentImagesResponse struct {
+	// digests are the requested digests of the images in the content store
+	Digests              []string `protobuf:"bytes,1,rep,name=digests" json:"digests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContentImagesResponse) Reset()         { *m = ContentImagesResponse{} }
+func (m *ContentImagesResponse) String() string { return proto.CompactTextString(m) }
+func (*ContentImagesResponse) ProtoMessage()    {}
+func (*ContentImagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{53}
+}
+func (m *ContentImagesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentImagesResponse.Unmarshal(m, b)
+}
+func (m *ContentImagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentImagesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ContentImagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentImagesResponse.Merge(dst, src)
+}
+func (m *ContentImagesResponse) XXX_Size() int {
+	return xxx_messageInfo_ContentImagesResponse.Size(m)
+}
+func (m *ContentImagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentImagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContentImagesResponse proto.InternalMessageInfo
+
+func (m *ContentImagesResponse) GetDigests() []string {
+	if m != nil {
+		return m.Digests
+	}
+	return nil
+}
+
+type ReadContentRequest struct {
+	Digest string `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	// offset is the byte offset to start reading from to resume a download
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadContentRequest) Reset()         { *m = ReadContentRequest{} }
+func (m *ReadContentRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadContentRequest) ProtoMessage()    {}
+func (*ReadContentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{54}
+}
+func (m *ReadContentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadContentRequest.Unmarshal(m, b)
+}
+func (m *ReadContentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadContentRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReadContentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadContentRequest.Merge(dst, src)
+}
+func (m *ReadContentRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadContentRequest.Size(m)
+}
+func (m *ReadContentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadContentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadContentRequest proto.InternalMessageInfo
+
+func (m *ReadContentRequest) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *ReadContentRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ContentChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContentChunk) Reset()         { *m = ContentChunk{} }
+func (m *ContentChunk) String() string { return proto.CompactTextString(m) }
+func (*ContentChunk) ProtoMessage()    {}
+func (*ContentChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_terra_4a3ff258c9125457, []int{55}
+}
+func (m *ContentChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentChunk.Unmarshal(m, b)
+}
+func (m *ContentChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentChunk.Marshal(b, m, deterministic)
+}
+func (dst *ContentChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentChunk.Merge(dst, src)
+}
+func (m *ContentChunk) XXX_Size() int {
+	return xxx_messageInfo_ContentChunk.Size(m)
+}
+func (m *ContentChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContentChunk proto.InternalMessageInfo
+
+func (m *ContentChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ListRequest)(nil), "io.stellarproject.terra.v1.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "io.stellarproject.terra.v1.ListResponse")
+	proto.RegisterType((*Assembly)(nil), "io.stellarproject.terra.v1.Assembly")
+	proto.RegisterMapType((map[string]string)(nil), "io.stellarproject.terra.v1.Assembly.ParametersEntry")
+	proto.RegisterMapType((map[string]string)(nil), "io.stellarproject.terra.v1.Assembly.VariablesEntry")
+	proto.RegisterType((*ResourceLimits)(nil), "io.stellarproject.terra.v1.ResourceLimits")
+	proto.RegisterType((*ResourceUsage)(nil), "io.stellarproject.terra.v1.ResourceUsage")
+	proto.RegisterType((*ExecutionPolicy)(nil), "io.stellarproject.terra.v1.ExecutionPolicy")
+	proto.RegisterType((*RetryPolicy)(nil), "io.stellarproject.terra.v1.RetryPolicy")
+	proto.RegisterType((*Manifest)(nil), "io.stellarproject.terra.v1.Manifest")
+	proto.RegisterMapType((map[string]string)(nil), "io.stellarproject.terra.v1.Manifest.LabelsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "io.stellarproject.terra.v1.Manifest.VariablesEntry")
+	proto.RegisterType((*ManifestList)(nil), "io.stellarproject.terra.v1.ManifestList")
+	proto.RegisterType((*ApplyRequest)(nil), "io.stellarproject.terra.v1.ApplyRequest")
+	proto.RegisterType((*NodesRequest)(nil), "io.stellarproject.terra.v1.NodesRequest")
+	proto.RegisterType((*Node)(nil), "io.stellarproject.terra.v1.Node")
+	proto.RegisterMapType((map[string]string)(nil), "io.stellarproject.terra.v1.Node.LabelsEntry")
+	proto.RegisterType((*NodesResponse)(nil), "io.stellarproject.terra.v1.NodesResponse")
+	proto.RegisterType((*StatusRequest)(nil), "io.stellarproject.terra.v1.StatusRequest")
+	proto.RegisterType((*NodeStatus)(nil), "io.stellarproject.terra.v1.NodeStatus")
+	proto.RegisterMapType((map[string]string)(nil), "io.stellarproject.terra.v1.NodeStatus.LabelsEntry")
+	proto.RegisterType((*AssemblyStatus)(nil), "io.stellarproject.terra.v1.AssemblyStatus")
+	proto.RegisterType((*StatusResponse)(nil), "io.stellarproject.terra.v1.StatusResponse")
+	proto.RegisterType((*UpdateRequest)(nil), "io.stellarproject.terra.v1.UpdateRequest")
+	proto.RegisterType((*RolloutRequest)(nil), "io.stellarproject.terra.v1.RolloutRequest")
+	proto.RegisterType((*Canary)(nil), "io.stellarproject.terra.v1.Canary")
+	proto.RegisterMapType((map[string]string)(nil), "io.stellarproject.terra.v1.Canary.LabelsEntry")
+	proto.RegisterType((*RolloutEvent)(nil), "io.stellarproject.terra.v1.RolloutEvent")
+	proto.RegisterType((*HealthRequest)(nil), "io.stellarproject.terra.v1.HealthRequest")
+	proto.RegisterType((*HealthResponse)(nil), "io.stellarproject.terra.v1.HealthResponse")
+	proto.RegisterType((*HistoryRequest)(nil), "io.stellarproject.terra.v1.HistoryRequest")
+	proto.RegisterType((*HistoryResponse)(nil), "io.stellarproject.terra.v1.HistoryResponse")
+	proto.RegisterType((*PlanRequest)(nil), "io.stellarproject.terra.v1.PlanRequest")
+	proto.RegisterType((*PlanResponse)(nil), "io.stellarproject.terra.v1.PlanResponse")
+	proto.RegisterType((*NodePlan)(nil), "io.stellarproject.terra.v1.NodePlan")
+	proto.RegisterType((*PlannedAssembly)(nil), "io.stellarproject.terra.v1.PlannedAssembly")
+	proto.RegisterType((*LogsRequest)(nil), "io.stellarproject.terra.v1.LogsRequest")
+	proto.RegisterType((*LogEntry)(nil), "io.stellarproject.terra.v1.LogEntry")
+	proto.RegisterType((*ExecutionsRequest)(nil), "io.stellarproject.terra.v1.ExecutionsRequest")
+	proto.RegisterType((*ExecutionsResponse)(nil), "io.stellarproject.terra.v1.ExecutionsResponse")
+	proto.RegisterType((*Execution)(nil), "io.stellarproject.terra.v1.Execution")
+	proto.RegisterType((*CancelRequest)(nil), "io.stellarproject.terra.v1.CancelRequest")
+	proto.RegisterType((*Secret)(nil), "io.stellarproject.terra.v1.Secret")
+	proto.RegisterType((*SetSecretRequest)(nil), "io.stellarproject.terra.v1.SetSecretRequest")
+	proto.RegisterType((*GetSecretRequest)(nil), "io.stellarproject.terra.v1.GetSecretRequest")
+	proto.RegisterType((*GetSecretResponse)(nil), "io.stellarproject.terra.v1.GetSecretResponse")
+	proto.RegisterType((*RemoveSecretRequest)(nil), "io.stellarproject.terra.v1.RemoveSecretRequest")
+	proto.RegisterType((*SecretsRequest)(nil), "io.stellarproject.terra.v1.SecretsRequest")
+	proto.RegisterType((*SecretsResponse)(nil), "io.stellarproject.terra.v1.SecretsResponse")
+	proto.RegisterType((*SealedSecret)(nil), "io.stellarproject.terra.v1.SealedSecret")
+	proto.RegisterType((*ReplicateSecretRequest)(nil), "io.stellarproject.terra.v1.ReplicateSecretRequest")
+	proto.RegisterType((*SealedSecretsRequest)(nil), "io.stellarproject.terra.v1.SealedSecretsRequest")
+	proto.RegisterType((*SealedSecretsResponse)(nil), "io.stellarproject.terra.v1.SealedSecretsResponse")
+	proto.RegisterType((*ContentRequest)(nil), "io.stellarproject.terra.v1.ContentRequest")
+	proto.RegisterType((*Blob)(nil), "io.stellarproject.terra.v1.Blob")
+	proto.RegisterType((*Download)(nil), "io.stellarproject.terra.v1.Download")
+	proto.RegisterType((*ContentResponse)(nil), "io.stellarproject.terra.v1.ContentResponse")
+	proto.RegisterType((*PruneContentRequest)(nil), "io.stellarproject.terra.v1.PruneContentRequest")
+	proto.RegisterType((*PruneContentResponse)(nil), "io.stellarproject.terra.v1.PruneContentResponse")
+	proto.RegisterType((*ContentImagesRequest)(nil), "io.stellarproject.terra.v1.ContentImagesRequest")
+	proto.RegisterType((*ContentImagesResponse)(nil), "io.stellarproject.terra.v1.ContentImagesResponse")
+	proto.RegisterType((*ReadContentRequest)(nil), "io.stellarproject.terra.v1.ReadContentRequest")
+	proto.RegisterType((*ContentChunk)(nil), "io.stellarproject.terra.v1.ContentChunk")
+	proto.RegisterEnum("io.stellarproject.terra.v1.NodeStatus_Status", NodeStatus_Status_name, NodeStatus_Status_value)
+	proto.RegisterEnum("io.stellarproject.terra.v1.AssemblyStatus_Status", AssemblyStatus_Status_name, AssemblyStatus_Status_value)
+	proto.RegisterEnum("io.stellarproject.terra.v1.RolloutEvent_Type", RolloutEvent_Type_name, RolloutEvent_Type_value)
+	proto.RegisterEnum("io.stellarproject.terra.v1.PlannedAssembly_Action", PlannedAssembly_Action_name, PlannedAssembly_Action_value)
+	proto.RegisterEnum("io.stellarproject.terra.v1.Execution_Trigger", Execution_Trigger_name, Execution_Trigger_value)
+	proto.RegisterEnum("io.stellarproject.terra.v1.Execution_Result", Execution_Result_name, Execution_Result_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Terra service
+
+type TerraClient interface {
+	// List is used to list manifests
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Apply applies a manifest directly to the node
+	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// Nodes returns a list of nodes
+	Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error)
+	// Status returns the current node status
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Update updates the current manifest list for the cluster
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// Rollout updates the cluster in batches and streams the progress
+	Rollout(ctx context.Context, in *RolloutRequest, opts ...grpc.CallOption) (Terra_RolloutClient, error)
+	// Health runs the health executable from an assembly on the node
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// History returns the stored manifest list revisions
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	// Plan returns the actions each node would take for a manifest list without applying it
+	Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanResponse, error)
+	// Logs streams the output of running and recent assembly executions
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Terra_LogsClient, error)
+	// Executions returns the recorded assembly executions
+	Executions(ctx context.Context, in *ExecutionsRequest, opts ...grpc.CallOption) (*ExecutionsResponse, error)
+	// Cancel aborts the in progress apply on a node
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// SetSecret stores an encrypted secret on every node
+	SetSecret(ctx context.Context, in *SetSecretRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// GetSecret returns the decrypted secret
+	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
+	// RemoveSecret removes the secret from every node
+	RemoveSecret(ctx context.Context, in *RemoveSecretRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// Secrets returns the stored secrets without their values
+	Secrets(ctx context.Context, in *SecretsRequest, opts ...grpc.CallOption) (*SecretsResponse, error)
+	// ReplicateSecret stores a secret sealed with the cluster key by a peer
+	ReplicateSecret(ctx context.Context, in *ReplicateSecretRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// SealedSecrets returns the stored secrets sealed with the cluster key for peers to sync
+	SealedSecrets(ctx context.Context, in *SealedSecretsRequest, opts ...grpc.CallOption) (*SealedSecretsResponse, error)
+	// Content returns the blobs in the node content store
+	Content(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*ContentResponse, error)
+	// PruneContent removes the blobs not referenced by a current or recent revision
+	PruneContent(ctx context.Context, in *PruneContentRequest, opts ...grpc.CallOption) (*PruneContentResponse, error)
+	// ContentImages returns the requested image digests fetched completely into the node content store
+	ContentImages(ctx context.Context, in *ContentImagesRequest, opts ...grpc.CallOption) (*ContentImagesResponse, error)
+	// ReadContent streams a blob from the node content store to a peer
+	ReadContent(ctx context.Context, in *ReadContentRequest, opts ...grpc.CallOption) (Terra_ReadContentClient, error)
+}
+
+type terraClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTerraClient(cc *grpc.ClientConn) TerraClient {
+	return &terraClient{cc}
+}
+
+func (c *terraClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Apply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error) {
+	out := new(NodesResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Nodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Rollout(ctx context.Context, in *RolloutRequest, opts ...grpc.CallOption) (Terra_RolloutClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Terra_serviceDesc.Streams[0], "/io.stellarproject.terra.v1.Terra/Rollout", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terraRolloutClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Terra_RolloutClient interface {
+	Recv() (*RolloutEvent, error)
+	grpc.ClientStream
+}
+
+type terraRolloutClient struct {
+	grpc.ClientStream
+}
+
+func (x *terraRolloutClient) Recv() (*RolloutEvent, error) {
+	m := new(RolloutEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *terraClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanResponse, error) {
+	out := new(PlanResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Plan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Terra_LogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Terra_serviceDesc.Streams[1], "/io.stellarproject.terra.v1.Terra/Logs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terraLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Terra_LogsClient interface {
+	Recv() (*LogEntry, error)
+	grpc.ClientStream
+}
+
+type terraLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *terraLogsClient) Recv() (*LogEntry, error) {
+	m := new(LogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *terraClient) Executions(ctx context.Context, in *ExecutionsRequest, opts ...grpc.CallOption) (*ExecutionsResponse, error) {
+	out := new(ExecutionsResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Executions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Cancel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) SetSecret(ctx context.Context, in *SetSecretRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/SetSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+	out := new(GetSecretResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/GetSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) RemoveSecret(ctx context.Context, in *RemoveSecretRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/RemoveSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Secrets(ctx context.Context, in *SecretsRequest, opts ...grpc.CallOption) (*SecretsResponse, error) {
+	out := new(SecretsResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Secrets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) ReplicateSecret(ctx context.Context, in *ReplicateSecretRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/ReplicateSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) SealedSecrets(ctx context.Context, in *SealedSecretsRequest, opts ...grpc.CallOption) (*SealedSecretsResponse, error) {
+	out := new(SealedSecretsResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/SealedSecrets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) Content(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*ContentResponse, error) {
+	out := new(ContentResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/Content", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) PruneContent(ctx context.Context, in *PruneContentRequest, opts ...grpc.CallOption) (*PruneContentResponse, error) {
+	out := new(PruneContentResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/PruneContent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) ContentImages(ctx context.Context, in *ContentImagesRequest, opts ...grpc.CallOption) (*ContentImagesResponse, error) {
+	out := new(ContentImagesResponse)
+	err := c.cc.Invoke(ctx, "/io.stellarproject.terra.v1.Terra/ContentImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraClient) ReadContent(ctx context.Context, in *ReadContentRequest, opts ...grpc.CallOption) (Terra_ReadContentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Terra_serviceDesc.Streams[2], "/io.stellarproject.terra.v1.Terra/ReadContent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terraReadContentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Terra_ReadContentClient interface {
+	Recv() (*ContentChunk, error)
+	grpc.ClientStream
+}
+
+type terraReadContentClient struct {
+	grpc.ClientStream
+}
+
+func (x *terraReadContentClient) Recv() (*ContentChunk, error) {
+	m := new(ContentChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Terra service
+
+type TerraServer interface {
+	// List is used to list manifests
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Apply applies a manifest directly to the node
+	Apply(context.Context, *ApplyRequest) (*types.Empty, error)
+	// Nodes returns a list of nodes
+	Nodes(context.Context, *NodesRequest) (*NodesResponse, error)
+	// Status returns the current node status
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// Update updates the current manifest list for the cluster
+	Update(context.Context, *UpdateRequest) (*types.Empty, error)
+	// Rollout updates the cluster in batches and streams the progress
+	Rollout(*RolloutRequest, Terra_RolloutServer) error
+	// Health runs the health executable from an assembly on the node
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	// History returns the stored manifest list revisions
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	// Plan returns the actions each node would take for a manifest list without applying it
+	Plan(context.Context, *PlanRequest) (*PlanResponse, error)
+	// Logs streams the output of running and recent assembly executions
+	Logs(*LogsRequest, Terra_LogsServer) error
+	// Executions returns the recorded assembly executions
+	Executions(context.Context, *ExecutionsRequest) (*ExecutionsResponse, error)
+	// Cancel aborts the in progress apply on a node
+	Cancel(context.Context, *CancelRequest) (*types.Empty, error)
+	// SetSecret stores an encrypted secret on every node
+	SetSecret(context.Context, *SetSecretRequest) (*types.Empty, error)
+	// GetSecret returns the decrypted secret
+	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
+	// RemoveSecret removes the secret from every node
+	RemoveSecret(context.Context, *RemoveSecretRequest) (*types.Empty, error)
+	// Secrets returns the stored secrets without their values
+	Secrets(context.Context, *SecretsRequest) (*SecretsResponse, error)
+	// ReplicateSecret stores a secret sealed with the cluster key by a peer
+	ReplicateSecret(context.Context, *ReplicateSecretRequest) (*types.Empty, error)
+	// SealedSecrets returns the stored secrets sealed with the cluster key for peers to sync
+	SealedSecrets(context.Context, *SealedSecretsRequest) (*SealedSecretsResponse, error)
+	// Content returns the blobs in the node content store
+	Content(context.Context, *ContentRequest) (*ContentResponse, error)
+	// PruneContent removes the blobs not referenced by a current or recent revision
+	PruneContent(context.Context, *PruneContentRequest) (*PruneContentResponse, error)
+	// ContentImages returns the requested image digests fetched completely into the node content store
+	ContentImages(context.Context, *ContentImagesRequest) (*ContentImagesResponse, error)
+	// ReadContent streams a blob from the node content store to a peer
+	ReadContent(*ReadContentRequest, Terra_ReadContentServer) error
+}
+
+func RegisterTerraServer(s *grpc.Server, srv TerraServer) {
+	s.RegisterService(&_Terra_serviceDesc, srv)
+}
+
+func _Terra_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Apply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Apply(ctx, req.(*ApplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Nodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Nodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Nodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Nodes(ctx, req.(*NodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Rollout_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RolloutRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerraServer).Rollout(m, &terraRolloutServer{stream})
+}
+
+type Terra_RolloutServer interface {
+	Send(*RolloutEvent) error
+	grpc.ServerStream
+}
+
+type terraRolloutServer struct {
+	grpc.ServerStream
+}
+
+func (x *terraRolloutServer) Send(m *RolloutEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Terra_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Plan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Plan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Plan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Plan(ctx, req.(*PlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Logs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerraServer).Logs(m, &terraLogsServer{stream})
+}
+
+type Terra_LogsServer interface {
+	Send(*LogEntry) error
+	grpc.ServerStream
+}
+
+type terraLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *terraLogsServer) Send(m *LogEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Terra_Executions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Executions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Executions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Executions(ctx, req.(*ExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Cancel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_SetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).SetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/SetSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).SetSecret(ctx, req.(*SetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).GetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/GetSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).GetSecret(ctx, req.(*GetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_RemoveSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).RemoveSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/RemoveSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).RemoveSecret(ctx, req.(*RemoveSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Secrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Secrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Secrets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Secrets(ctx, req.(*SecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_ReplicateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicateSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).ReplicateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/ReplicateSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).ReplicateSecret(ctx, req.(*ReplicateSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_SealedSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SealedSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).SealedSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/SealedSecrets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).SealedSecrets(ctx, req.(*SealedSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_Content_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).Content(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/Content",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).Content(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_PruneContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).PruneContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/PruneContent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).PruneContent(ctx, req.(*PruneContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_ContentImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraServer).ContentImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.stellarproject.terra.v1.Terra/ContentImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraServer).ContentImages(ctx, req.(*ContentImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Terra_ReadContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadContentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerraServer).ReadContent(m, &terraReadContentServer{stream})
+}
+
+type Terra_ReadContentServer interface {
+	Send(*ContentChunk) error
+	grpc.ServerStream
+}
+
+type terraReadContentServer struct {
+	grpc.ServerStream
+}
+
+func (x *terraReadContentServer) Send(m *ContentChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Terra_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "io.stellarproject.terra.v1.Terra",
+	HandlerType: (*TerraServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Terra_List_Handler,
+		},
+		{
+			MethodName: "Apply",
+			Handler:    _Terra_Apply_Handler,
+		},
+		{
+			MethodName: "Nodes",
+			Handler:    _Terra_Nodes_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Terra_Status_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _Terra_Update_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _Terra_Health_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _Terra_History_Handler,
+		},
+		{
+			MethodName: "Plan",
+			Handler:    _Terra_Plan_Handler,
+		},
+		{
+			MethodName: "Executions",
+			Handler:    _Terra_Executions_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _Terra_Cancel_Handler,
+		},
+		{
+			MethodName: "SetSecret",
+			Handler:    _Terra_SetSecret_Handler,
+		},
+		{
+			MethodName: "GetSecret",
+			Handler:    _Terra_GetSecret_Handler,
+		},
+		{
+			MethodName: "RemoveSecret",
+			Handler:    _Terra_RemoveSecret_Handler,
+		},
+		{
+			MethodName: "Secrets",
+			Handler:    _Terra_Secrets_Handler,
+		},
+		{
+			MethodName: "ReplicateSecret",
+			Handler:    _Terra_ReplicateSecret_Handler,
+		},
+		{
+			MethodName: "SealedSecrets",
+			Handler:    _Terra_SealedSecrets_Handler,
+		},
+		{
+			MethodName: "Content",
+			Handler:    _Terra_Content_Handler,
+		},
+		{
+			MethodName: "PruneContent",
+			Handler:    _Terra_PruneContent_Handler,
+		},
+		{
+			MethodName: "ContentImages",
+			Handler:    _Terra_ContentImages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Rollout",
+			Handler:       _Terra_Rollout_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Logs",
+			Handler:       _Terra_Logs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadContent",
+			Handler:       _Terra_ReadContent_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "github.com/stellarproject/terra/api/v1/terra.proto",
+}
+
+func init() {
+	proto.RegisterFile("github.com/stellarproject/terra/api/v1/terra.proto", fileDescriptor_terra_4a3ff258c9125457)
+}
+
+var fileDescriptor_terra_4a3ff258c9125457 = []byte{
+	// 3345 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0xcd, 0x73, 0x1b, 0xc7,
+	0x95, 0xf7, 0x00, 0x83, 0x01, 0xf0, 0xf0, 0x41, 0xa8, 0x4d, 0x73, 0x61, 0x78, 0xd7, 0xd4, 0xce,
+	0xda, 0x16, 0x25, 0xd9, 0xa0, 0x44, 0xbb, 0x5c, 0x5e, 0xaf, 0xfc, 0x81, 0x2f, 0x89, 0x90, 0x28,
+	0x82, 0x6a, 0x82, 0xf6, 0xd2, 0x5e, 0x17, 0x6a, 0x80, 0x69, 0x92, 0x63, 0x0d, 0x66, 0xe0, 0x99,
+	0x01, 0x4d, 0xf8, 0xb8, 0x7b, 0xdb, 0x4a, 0x2a, 0xae, 0x9c, 0xf2, 0x27, 0xe4, 0x94, 0x43, 0x6e,
+	0xc9, 0x5f, 0x90, 0xe4, 0x9a, 0xaa, 0x1c, 0x99, 0x2a, 0x55, 0xae, 0x39, 0x25, 0x87, 0xe4, 0x96,
+	0xea, 0x8f, 0x19, 0xcc, 0x40, 0x04, 0x30, 0x34, 0x53, 0xba, 0xcd, 0x6b, 0xf4, 0x7b, 0xfd, 0xfa,
+	0xbd, 0xd7, 0xbf, 0xfe, 0x75, 0x37, 0x60, 0xeb, 0xd8, 0xf0, 0x4e, 0xc6, 0xfd, 0xea, 0xc0, 0x1e,
+	0x6e, 0xba, 0x1e, 0x31, 0x4d, 0xcd, 0x19, 0x39, 0xf6, 0xd7, 0x64, 0xe0, 0x6d, 0x7a, 0xc4, 0x71,
+	0xb4, 0x4d, 0x6d, 0x64, 0x6c, 0x9e, 0xde, 0xe5, 0x42, 0x75, 0xe4, 0xd8, 0x9e, 0x8d, 0x2a, 0x86,
+	0x5d, 0x8d, 0xf6, 0xad, 0xf2, 0x9f, 0x4f, 0xef, 0x56, 0x56, 0x8f, 0xed, 0x63, 0x9b, 0x75, 0xdb,
+	0xa4, 0x5f, 0x5c, 0xa3, 0xb2, 0x7e, 0x6c, 0xdb, 0xc7, 0x26, 0xd9, 0x64, 0x52, 0x7f, 0x7c, 0xb4,
+	0xe9, 0x19, 0x43, 0xe2, 0x7a, 0xda, 0x70, 0x24, 0x3a, 0xbc, 0x36, 0xdb, 0x81, 0x0c, 0x47, 0xde,
+	0x44, 0xfc, 0xf8, 0xfa, 0xec, 0x8f, 0xfa, 0xd8, 0xd1, 0x3c, 0xc3, 0xb6, 0xf8, 0xef, 0x6a, 0x01,
+	0x72, 0x3b, 0x86, 0xeb, 0x61, 0xf2, 0xcd, 0x98, 0xb8, 0x9e, 0xfa, 0x15, 0xe4, 0xb9, 0xe8, 0x8e,
+	0x6c, 0xcb, 0x25, 0xe8, 0x31, 0x14, 0x86, 0x9a, 0x65, 0x1c, 0x11, 0xd7, 0xeb, 0x99, 0x86, 0xeb,
+	0x95, 0xa5, 0xeb, 0xd2, 0x46, 0x6e, 0x6b, 0xa3, 0x3a, 0x7f, 0x1a, 0xd5, 0xc7, 0x42, 0x81, 0x19,
+	0xca, 0x0f, 0x43, 0x92, 0xfa, 0x3b, 0x19, 0x32, 0x35, 0xd7, 0x25, 0xc3, 0xbe, 0x39, 0x41, 0xab,
+	0x90, 0x32, 0x86, 0xda, 0x31, 0x61, 0x36, 0xb3, 0x98, 0x0b, 0xa8, 0x02, 0x19, 0x87, 0x7c, 0x33,
+	0x36, 0x1c, 0xe2, 0x96, 0x13, 0xd7, 0x93, 0x1b, 0x59, 0x1c, 0xc8, 0xa8, 0x0b, 0x30, 0xd2, 0x1c,
+	0x6d, 0x48, 0x3c, 0xe2, 0xb8, 0xe5, 0xe4, 0xf5, 0xe4, 0x46, 0x6e, 0xeb, 0xbd, 0x45, 0xae, 0xf8,
+	0x63, 0x55, 0xf7, 0x02, 0xb5, 0x96, 0xe5, 0x39, 0x13, 0x1c, 0xb2, 0x83, 0xca, 0x90, 0xa6, 0x21,
+	0xb5, 0xc7, 0x5e, 0x59, 0x66, 0x9e, 0xf8, 0x22, 0xfa, 0x08, 0x52, 0x0e, 0xf1, 0x9c, 0x49, 0x39,
+	0xc5, 0x66, 0x7d, 0x63, 0xd1, 0x50, 0x98, 0x76, 0xdc, 0xb3, 0x4d, 0x63, 0x30, 0xc1, 0x5c, 0x0b,
+	0x35, 0x40, 0x19, 0xb1, 0x86, 0xb2, 0xc2, 0xf4, 0x6f, 0x2f, 0xd2, 0x6f, 0x9d, 0x91, 0xc1, 0x98,
+	0x26, 0x46, 0xd8, 0x10, 0xaa, 0x68, 0x1b, 0xb2, 0x0e, 0x71, 0xed, 0xb1, 0x33, 0x20, 0x6e, 0x39,
+	0xcd, 0xec, 0xdc, 0x5a, 0xec, 0x07, 0xef, 0xbc, 0x63, 0x0c, 0x0d, 0xcf, 0xc5, 0x53, 0x65, 0xf4,
+	0x04, 0xb2, 0xa7, 0x9a, 0x63, 0x68, 0x7d, 0x93, 0xb8, 0xe5, 0x0c, 0x0b, 0xde, 0xbb, 0xb1, 0x82,
+	0xf7, 0x99, 0xaf, 0xc5, 0x63, 0x37, 0xb5, 0x52, 0xf9, 0x08, 0x56, 0x66, 0x22, 0x8b, 0x4a, 0x90,
+	0x7c, 0x4a, 0x26, 0x22, 0xa7, 0xf4, 0x93, 0xe6, 0xf9, 0x54, 0x33, 0xc7, 0xa4, 0x9c, 0xe0, 0x79,
+	0x66, 0xc2, 0x87, 0x89, 0x0f, 0xa4, 0xca, 0x3d, 0x28, 0x46, 0x6d, 0x5f, 0x46, 0x5b, 0xb5, 0xa1,
+	0x18, 0x9d, 0x2c, 0x7a, 0x15, 0x92, 0x83, 0xd1, 0x98, 0x6b, 0xd7, 0xd3, 0xcf, 0xce, 0xd7, 0x93,
+	0x8d, 0xbd, 0x03, 0x4c, 0xdb, 0xd0, 0x1a, 0x28, 0x43, 0x32, 0xb4, 0x9d, 0x89, 0xb0, 0x23, 0x24,
+	0x84, 0x40, 0x1e, 0x19, 0x3a, 0x2d, 0x26, 0x69, 0x43, 0xc6, 0xec, 0x1b, 0xad, 0x41, 0xc2, 0xb0,
+	0xcb, 0x32, 0x2d, 0xbe, 0xba, 0xf2, 0xec, 0x7c, 0x3d, 0xd1, 0xee, 0xe0, 0x84, 0x61, 0xab, 0x7f,
+	0x93, 0xa0, 0xe0, 0x8f, 0x78, 0xe0, 0xd2, 0x62, 0x5d, 0x87, 0x1c, 0xb7, 0xd3, 0x1b, 0x11, 0xed,
+	0x29, 0x1b, 0x58, 0xc6, 0xc0, 0x9b, 0xf6, 0x88, 0xf6, 0x14, 0xdd, 0xe3, 0x1e, 0x25, 0x58, 0xde,
+	0x5e, 0xad, 0xf2, 0xc5, 0x58, 0xf5, 0x17, 0x63, 0xb5, 0x29, 0x16, 0x63, 0x7d, 0xe5, 0x37, 0xe7,
+	0xeb, 0x2f, 0x09, 0x87, 0x7f, 0xf6, 0xc7, 0x75, 0x89, 0x3b, 0xfd, 0x1a, 0x64, 0xa9, 0x43, 0xdc,
+	0x38, 0xf7, 0x30, 0x43, 0x1b, 0x98, 0xe9, 0x77, 0xa1, 0x60, 0xd8, 0x3d, 0x87, 0x68, 0x7a, 0xaf,
+	0x3f, 0xf1, 0x88, 0xcb, 0x8a, 0x57, 0xae, 0xaf, 0x3c, 0x3b, 0x5f, 0xcf, 0xb5, 0x3b, 0x98, 0x68,
+	0x7a, 0x9d, 0x36, 0xe3, 0x9c, 0x61, 0x07, 0x02, 0x7a, 0x1f, 0x8a, 0x86, 0xdd, 0xfb, 0xd6, 0x31,
+	0x3c, 0x22, 0xb4, 0x52, 0x4c, 0xab, 0xf4, 0xec, 0x7c, 0x3d, 0xdf, 0xee, 0x7c, 0x4e, 0x7f, 0xe0,
+	0x6a, 0x79, 0xc3, 0x9e, 0x4a, 0xea, 0xf7, 0x12, 0xac, 0xcc, 0x54, 0x28, 0x0d, 0xdd, 0xd8, 0x25,
+	0x8e, 0x48, 0x16, 0xfb, 0x46, 0xaf, 0x03, 0x58, 0xda, 0x90, 0xb8, 0x23, 0x6d, 0xc0, 0xd6, 0xaf,
+	0xb4, 0x91, 0xc1, 0xa1, 0x16, 0x9a, 0x5f, 0x62, 0x9d, 0xb2, 0xa5, 0x9b, 0xc5, 0xf4, 0x93, 0xce,
+	0x91, 0xcd, 0xc1, 0xb6, 0xcc, 0x09, 0x9b, 0x42, 0x86, 0x2e, 0x78, 0x4d, 0xef, 0x58, 0xe6, 0x84,
+	0x82, 0x01, 0xf5, 0x95, 0x16, 0x48, 0x39, 0xc5, 0xc1, 0xc0, 0x97, 0xd5, 0xff, 0x93, 0x20, 0x17,
+	0x5a, 0x74, 0xe8, 0xdf, 0x21, 0x3f, 0xd4, 0xce, 0x7a, 0x9a, 0xe7, 0x51, 0xfc, 0x73, 0x99, 0x5b,
+	0x05, 0x9c, 0x1b, 0x6a, 0x67, 0x35, 0xd1, 0x44, 0x57, 0x7a, 0x5f, 0x1b, 0x3c, 0xb5, 0x8f, 0x8e,
+	0x44, 0x15, 0xf8, 0x22, 0x4b, 0xa4, 0x76, 0xd6, 0xf3, 0x7f, 0x4d, 0xb2, 0x5f, 0x61, 0xa8, 0x9d,
+	0xd5, 0x45, 0x87, 0x35, 0x50, 0xbe, 0x36, 0x3c, 0x8f, 0x38, 0xcc, 0x47, 0x09, 0x0b, 0x49, 0xfd,
+	0xb3, 0x0c, 0x19, 0x1f, 0xf0, 0xd0, 0x7f, 0x40, 0xda, 0xb2, 0x75, 0xd2, 0x33, 0x74, 0x51, 0x83,
+	0xf0, 0xec, 0x7c, 0x5d, 0xd9, 0xb5, 0x75, 0xd2, 0x6e, 0x62, 0x85, 0xfe, 0xd4, 0xd6, 0xd1, 0x36,
+	0x28, 0xa6, 0xd6, 0x27, 0x26, 0x87, 0xb7, 0xdc, 0xd6, 0x9d, 0x38, 0x58, 0x5a, 0xdd, 0x61, 0x2a,
+	0x7c, 0x01, 0x0a, 0x7d, 0xd4, 0x04, 0xd0, 0xf8, 0x1a, 0x35, 0x88, 0x0f, 0x87, 0x6f, 0xc4, 0x59,
+	0xd1, 0x38, 0xa4, 0x37, 0x05, 0x39, 0xf9, 0x8a, 0x20, 0x97, 0xfa, 0xe1, 0x20, 0x17, 0x81, 0x26,
+	0x65, 0x39, 0x34, 0x05, 0x61, 0x99, 0x0b, 0x4d, 0xb4, 0x74, 0x5c, 0x62, 0x92, 0x81, 0x67, 0x3b,
+	0x0c, 0x36, 0xb3, 0x38, 0x90, 0xd1, 0x5b, 0x90, 0x11, 0x79, 0xe2, 0x40, 0x98, 0xad, 0xe7, 0x9e,
+	0x9d, 0xaf, 0xa7, 0x79, 0xa2, 0x5c, 0x9c, 0xe6, 0x99, 0x62, 0xf5, 0x42, 0xce, 0x06, 0xe6, 0x58,
+	0x27, 0xe5, 0x2c, 0xab, 0x3e, 0x5f, 0xac, 0xfc, 0x27, 0xe4, 0x42, 0x19, 0x79, 0x81, 0xa0, 0xf7,
+	0x0b, 0x09, 0xf2, 0xe1, 0x0d, 0x16, 0xd5, 0x21, 0xeb, 0x6f, 0xb1, 0xb4, 0xe6, 0x97, 0xd6, 0x80,
+	0xaf, 0x8c, 0xa7, 0x6a, 0xe8, 0x63, 0x48, 0x8f, 0x47, 0xba, 0xe6, 0x11, 0x5d, 0x20, 0x55, 0xe5,
+	0x39, 0xa4, 0xea, 0xfa, 0xa4, 0xa3, 0x9e, 0xa1, 0x50, 0xf5, 0x3d, 0xc5, 0x28, 0x5f, 0x89, 0xef,
+	0xd9, 0xa7, 0x86, 0x6b, 0xd8, 0x96, 0x0f, 0x53, 0xbe, 0xac, 0xfe, 0x4a, 0x82, 0x7c, 0x6d, 0x34,
+	0x32, 0x27, 0x82, 0x62, 0xfc, 0x93, 0x29, 0x05, 0x0d, 0xd5, 0x91, 0xed, 0x0c, 0x88, 0x00, 0x1b,
+	0x2e, 0xa0, 0x26, 0x64, 0x46, 0xd4, 0x05, 0x7b, 0xcc, 0xa1, 0xfd, 0x32, 0xf6, 0x03, 0x4d, 0xf5,
+	0x16, 0xe4, 0x69, 0x4d, 0xb8, 0xbe, 0xeb, 0xe1, 0x9a, 0x92, 0xa2, 0x35, 0xa5, 0xfe, 0x5d, 0x02,
+	0x99, 0x76, 0x66, 0xbb, 0x87, 0xbf, 0xfe, 0xf9, 0xee, 0xd1, 0xc4, 0x09, 0x43, 0xa7, 0xc5, 0xa4,
+	0xe9, 0xba, 0x43, 0x5c, 0xd7, 0x07, 0x1f, 0x21, 0xa2, 0x66, 0x80, 0x08, 0x7c, 0x0d, 0xbf, 0xbd,
+	0xc8, 0x55, 0x3a, 0xc6, 0x85, 0x68, 0xf0, 0x31, 0x28, 0xae, 0xa7, 0x79, 0x63, 0x57, 0x2c, 0xe4,
+	0xb7, 0x96, 0x59, 0xd9, 0x67, 0xbd, 0xb1, 0xd0, 0xba, 0x42, 0x49, 0xab, 0x0f, 0xa0, 0x20, 0xe2,
+	0x24, 0x68, 0xe3, 0xfb, 0x90, 0xa2, 0x6b, 0xc8, 0x2f, 0xc8, 0xeb, 0xcb, 0x5c, 0xc1, 0xbc, 0xbb,
+	0xba, 0x02, 0x05, 0xe1, 0x95, 0xe0, 0xa3, 0xff, 0x9b, 0x04, 0x98, 0xfa, 0x8a, 0x5a, 0xc1, 0x1c,
+	0xa9, 0x5f, 0xc5, 0xad, 0x77, 0xe2, 0xcd, 0xb1, 0x1a, 0x9d, 0x2a, 0xba, 0x0e, 0x39, 0x9d, 0xb8,
+	0x03, 0xc7, 0x18, 0x51, 0x2c, 0x12, 0xf3, 0x09, 0x37, 0xa1, 0x87, 0x17, 0x40, 0xeb, 0xad, 0x38,
+	0xd0, 0x2a, 0x46, 0x0a, 0x69, 0xa3, 0x87, 0x41, 0x7a, 0x65, 0x66, 0x67, 0x2b, 0xa6, 0xd3, 0x17,
+	0x24, 0xf9, 0x2a, 0x49, 0xfa, 0x00, 0x14, 0x11, 0xc5, 0x1c, 0xa4, 0x0f, 0x76, 0x1f, 0xed, 0x76,
+	0x3e, 0xdf, 0x2d, 0xbd, 0x84, 0x14, 0x48, 0x74, 0x1e, 0x95, 0x24, 0x94, 0x87, 0xcc, 0xc1, 0x5e,
+	0xb3, 0xd6, 0x6d, 0xef, 0x3e, 0x28, 0x25, 0x68, 0x97, 0xfb, 0xb5, 0xf6, 0xce, 0x01, 0x6e, 0x95,
+	0x92, 0xea, 0x5f, 0x13, 0x50, 0x8c, 0xce, 0x6f, 0x0e, 0x77, 0x6f, 0x07, 0xe9, 0x49, 0xb0, 0xf4,
+	0xdc, 0x8d, 0x1f, 0xb1, 0x25, 0x29, 0x4a, 0x3e, 0x9f, 0xa2, 0x35, 0x50, 0x74, 0xe3, 0x98, 0xb8,
+	0x3e, 0x6b, 0x17, 0x12, 0x5d, 0xa4, 0x01, 0x07, 0x48, 0x31, 0x0e, 0x10, 0xc8, 0xe8, 0x13, 0x00,
+	0x8b, 0x9c, 0x79, 0x3d, 0xbe, 0xe1, 0x29, 0x4b, 0xb1, 0x4e, 0x66, 0x38, 0x97, 0xa5, 0x3a, 0x6c,
+	0xeb, 0xa3, 0x9c, 0xc7, 0x19, 0x9b, 0x44, 0xec, 0x28, 0xec, 0x5b, 0xfd, 0xec, 0xe2, 0xc0, 0xe6,
+	0x20, 0x5d, 0xdb, 0xdb, 0xdb, 0x69, 0xb7, 0x9a, 0x25, 0x89, 0x0a, 0xb8, 0xf5, 0xb8, 0xf3, 0x59,
+	0xab, 0x39, 0x13, 0x5c, 0x2a, 0xec, 0x3f, 0x6a, 0xef, 0xed, 0xb5, 0x9a, 0x25, 0x99, 0x0a, 0x4d,
+	0xdc, 0xbe, 0xdf, 0x6d, 0x35, 0x4b, 0x29, 0xf5, 0x10, 0x8a, 0xfe, 0x62, 0x10, 0xcb, 0xea, 0x01,
+	0xe4, 0xd8, 0xbe, 0x15, 0x5a, 0x03, 0xf1, 0xd7, 0x39, 0x58, 0xc1, 0xb7, 0xea, 0x41, 0xe1, 0x80,
+	0x61, 0xf7, 0x8b, 0x04, 0x65, 0xf5, 0x4f, 0x09, 0x28, 0x62, 0xdb, 0x34, 0xed, 0xb1, 0xf7, 0x42,
+	0x37, 0x83, 0x7f, 0x03, 0xe8, 0x6b, 0xde, 0xe0, 0xa4, 0xe7, 0x1a, 0xdf, 0x11, 0x56, 0x4a, 0x05,
+	0x9c, 0x65, 0x2d, 0xfb, 0xc6, 0x77, 0x04, 0xdd, 0x80, 0x15, 0xca, 0xfd, 0xc6, 0x96, 0x76, 0xaa,
+	0x19, 0x26, 0xe3, 0x9a, 0x32, 0xeb, 0x53, 0x1c, 0x6a, 0x67, 0x07, 0xd3, 0x56, 0x9f, 0x61, 0x1e,
+	0x69, 0x86, 0x39, 0x76, 0x88, 0x5f, 0x5d, 0x94, 0x38, 0xde, 0x17, 0x4d, 0xe8, 0x3e, 0xe4, 0x59,
+	0x86, 0xfc, 0x03, 0xa5, 0xb2, 0x8c, 0xf8, 0xb3, 0xdd, 0x94, 0x31, 0x7e, 0x96, 0xda, 0x2e, 0xd7,
+	0x43, 0x1f, 0x82, 0x32, 0xd0, 0x2c, 0xcd, 0x99, 0x88, 0x23, 0x9f, 0xba, 0x28, 0x20, 0x0d, 0xd6,
+	0x13, 0x0b, 0x0d, 0xf5, 0x27, 0x49, 0x50, 0x78, 0x13, 0xba, 0x1f, 0x40, 0x0f, 0x07, 0xe2, 0xea,
+	0x72, 0x33, 0x17, 0xee, 0x2d, 0x65, 0x48, 0x8f, 0x88, 0x33, 0x20, 0x96, 0xc7, 0x22, 0x5b, 0xc0,
+	0xbe, 0x88, 0x3e, 0x85, 0x6c, 0x5f, 0x7b, 0xca, 0x27, 0x5c, 0x4e, 0xc6, 0x9f, 0x6d, 0x86, 0x6a,
+	0xd1, 0xd9, 0xa2, 0x1d, 0x58, 0x39, 0x21, 0x9a, 0xe9, 0x9d, 0xf4, 0x0c, 0xcb, 0x23, 0xce, 0xa9,
+	0x66, 0x96, 0xe5, 0xf8, 0x76, 0x8a, 0x5c, 0xb7, 0x2d, 0x54, 0xd1, 0x3d, 0x50, 0x78, 0x8b, 0xa0,
+	0xa3, 0xf1, 0xf8, 0xb0, 0xd0, 0x89, 0x6c, 0xf0, 0x4a, 0x74, 0x83, 0xbf, 0x0a, 0xf4, 0xfe, 0x3e,
+	0x09, 0x79, 0x51, 0xf8, 0xad, 0x53, 0x1a, 0xb5, 0x1a, 0xc8, 0xde, 0x64, 0x44, 0xe2, 0xec, 0x62,
+	0x61, 0xbd, 0x6a, 0x77, 0x32, 0x22, 0x98, 0xa9, 0xd2, 0xd1, 0x58, 0x09, 0x8b, 0x84, 0x70, 0x21,
+	0x7c, 0x02, 0x49, 0xce, 0x3d, 0x81, 0xcc, 0x60, 0xab, 0xfc, 0x3c, 0xb6, 0xd6, 0x21, 0x1b, 0xdc,
+	0x32, 0x95, 0x53, 0x4b, 0x61, 0x72, 0x4a, 0x09, 0xa7, 0x6a, 0xea, 0xb9, 0x04, 0x32, 0xf5, 0x37,
+	0x8a, 0x8a, 0xd7, 0xa0, 0x50, 0xaf, 0x75, 0x1b, 0xdb, 0xbd, 0xfd, 0x6e, 0x0d, 0x77, 0x19, 0x36,
+	0x5e, 0x83, 0xc2, 0x6e, 0xa7, 0xd9, 0xea, 0x45, 0xb7, 0x1f, 0xd6, 0xd4, 0x79, 0x54, 0x4a, 0xa2,
+	0x15, 0xc8, 0x31, 0x81, 0x62, 0x26, 0x43, 0x49, 0x04, 0x45, 0x6e, 0xa3, 0xd1, 0x79, 0xbc, 0xb7,
+	0xd3, 0xea, 0xb6, 0x4a, 0x29, 0xba, 0x7d, 0x05, 0x92, 0x82, 0x00, 0x94, 0xed, 0xda, 0x0e, 0x35,
+	0x9f, 0xa6, 0xbd, 0x1b, 0xb5, 0xdd, 0x1a, 0x3e, 0x0c, 0x86, 0xcc, 0x84, 0xda, 0xb6, 0x5b, 0xb5,
+	0x9d, 0xee, 0xf6, 0x61, 0x29, 0x8b, 0x56, 0xa1, 0x24, 0xda, 0x0e, 0x76, 0xfd, 0x56, 0xa0, 0x76,
+	0xf7, 0x70, 0xe7, 0x71, 0x87, 0xea, 0xe5, 0x18, 0xa6, 0xd7, 0x3b, 0xcc, 0x48, 0x5e, 0x7d, 0x02,
+	0x85, 0x6d, 0x56, 0x36, 0x3e, 0x98, 0x7d, 0x0a, 0x19, 0xb1, 0xed, 0x4f, 0xca, 0xd2, 0x25, 0xaa,
+	0x2f, 0xd0, 0x52, 0xeb, 0x50, 0xf4, 0x4d, 0x0a, 0xc8, 0x2f, 0x43, 0x9a, 0xd7, 0x26, 0x37, 0x99,
+	0xc1, 0xbe, 0x48, 0xf7, 0x3f, 0x7b, 0xec, 0x8d, 0xc6, 0x9e, 0x7f, 0xa3, 0xc1, 0x25, 0xb5, 0x04,
+	0xc5, 0x6d, 0xc3, 0xf5, 0x6c, 0xc7, 0x67, 0xdc, 0xea, 0x21, 0xac, 0x04, 0x2d, 0xc2, 0xec, 0x7d,
+	0xc8, 0xfa, 0x0c, 0xdd, 0xc7, 0x86, 0xf8, 0x98, 0x3b, 0x55, 0x55, 0x7f, 0x24, 0x41, 0x6e, 0xcf,
+	0xd4, 0xac, 0x17, 0x8a, 0xe7, 0x65, 0x48, 0x0f, 0xcc, 0xb1, 0xeb, 0x11, 0x87, 0x15, 0x79, 0x06,
+	0xfb, 0xa2, 0xfa, 0x10, 0xf2, 0xdc, 0x1b, 0x31, 0xcd, 0x0f, 0xa3, 0x3c, 0xf4, 0x8d, 0x65, 0x5b,
+	0x25, 0x53, 0x16, 0x5c, 0xf4, 0xa7, 0x12, 0x64, 0xfc, 0xb6, 0x78, 0x27, 0xfb, 0x47, 0x11, 0xd2,
+	0xc8, 0x4f, 0xf7, 0x0b, 0x8f, 0xc3, 0xd4, 0xb4, 0x45, 0xf4, 0x0b, 0x8f, 0xe5, 0xab, 0x90, 0x22,
+	0x8e, 0x63, 0x3b, 0x82, 0xfa, 0x70, 0x41, 0xfd, 0x79, 0x02, 0x56, 0x66, 0xb4, 0xe6, 0x70, 0xb1,
+	0x87, 0xa0, 0x68, 0x83, 0x80, 0xde, 0x16, 0x17, 0xb3, 0xce, 0x19, 0x93, 0xd5, 0x1a, 0xd3, 0xc4,
+	0xc2, 0x42, 0x88, 0x6a, 0x25, 0x23, 0x54, 0xeb, 0x4d, 0x28, 0x6a, 0xa3, 0x91, 0x69, 0x10, 0xbd,
+	0x17, 0xa1, 0x62, 0x05, 0xd1, 0xda, 0xe4, 0xdd, 0xd6, 0x40, 0x71, 0x88, 0xe6, 0xda, 0x16, 0x83,
+	0x92, 0x2c, 0x16, 0x52, 0x40, 0xa6, 0x94, 0x10, 0x99, 0xda, 0x06, 0x85, 0x0f, 0xfe, 0x1c, 0x99,
+	0x6a, 0xef, 0xee, 0x77, 0x6b, 0x3b, 0x3b, 0x3e, 0x99, 0xa2, 0xdc, 0xea, 0xb0, 0x94, 0x40, 0x19,
+	0x90, 0x29, 0x7f, 0x2a, 0x25, 0x51, 0x01, 0xb2, 0x07, 0xbb, 0x7e, 0x2f, 0x59, 0x35, 0x20, 0xb7,
+	0x63, 0x1f, 0x07, 0x67, 0xb7, 0x35, 0x50, 0x8e, 0x28, 0x94, 0x7e, 0x2b, 0xd6, 0x91, 0x90, 0xa6,
+	0xd1, 0x4b, 0x84, 0xa3, 0x87, 0x40, 0xf6, 0x34, 0xc3, 0x14, 0x64, 0x81, 0x7d, 0x87, 0xcb, 0x4e,
+	0x8e, 0x96, 0xdd, 0x5f, 0x24, 0xc8, 0xec, 0xd8, 0xc7, 0x7c, 0x63, 0x88, 0x55, 0x2a, 0x17, 0x8f,
+	0xfa, 0x3a, 0x00, 0xa1, 0x36, 0x46, 0xb6, 0x61, 0xf9, 0xb1, 0x0e, 0xb5, 0xd0, 0x39, 0xb8, 0x9e,
+	0x43, 0xb4, 0xa1, 0x4f, 0x79, 0xb9, 0x44, 0xbd, 0x35, 0x0d, 0x8b, 0x88, 0xf0, 0xb2, 0xef, 0x28,
+	0x84, 0x2b, 0x3f, 0x08, 0xc2, 0xd1, 0xbf, 0x42, 0x96, 0xf8, 0x37, 0x36, 0x8c, 0x88, 0xc8, 0x78,
+	0xda, 0xa0, 0xea, 0x70, 0x2d, 0xb8, 0xcf, 0x09, 0xc2, 0x7c, 0x85, 0xd9, 0xaf, 0x42, 0xca, 0xa4,
+	0xf7, 0xb8, 0x22, 0xe8, 0x5c, 0x50, 0xbf, 0x04, 0x14, 0x1e, 0x45, 0x2c, 0xec, 0x16, 0x40, 0xe0,
+	0x88, 0xbf, 0xba, 0xdf, 0x8c, 0x75, 0xf3, 0x84, 0x43, 0x8a, 0xea, 0xaf, 0x15, 0xc8, 0x06, 0xbf,
+	0x84, 0x4e, 0xee, 0x72, 0xe4, 0xe4, 0x1e, 0x9a, 0x53, 0x62, 0xf9, 0x9c, 0x92, 0xf3, 0x33, 0x2a,
+	0x5f, 0x94, 0x51, 0xb1, 0x72, 0x52, 0x91, 0x95, 0x75, 0x03, 0x56, 0xa6, 0x2f, 0x14, 0xbd, 0x13,
+	0xcd, 0x3d, 0x11, 0xab, 0xa4, 0x38, 0x6d, 0xde, 0xd6, 0xdc, 0x13, 0xf4, 0x00, 0xd2, 0x9e, 0x63,
+	0x1c, 0x1f, 0x13, 0x7e, 0xcb, 0xb5, 0x84, 0x4c, 0x04, 0x73, 0xad, 0x76, 0xb9, 0x12, 0xf6, 0xb5,
+	0xe9, 0x25, 0x84, 0x43, 0xdc, 0xb1, 0xe9, 0x95, 0x33, 0xcc, 0xce, 0xdb, 0xf1, 0xec, 0x60, 0xa6,
+	0x83, 0x85, 0x2e, 0xbd, 0x49, 0x72, 0x3d, 0xcd, 0xa1, 0x37, 0x49, 0xd9, 0xcb, 0xdc, 0x24, 0x09,
+	0x25, 0xba, 0x85, 0x1e, 0x19, 0x96, 0xe1, 0x9e, 0x10, 0xbd, 0x0c, 0x97, 0x30, 0x10, 0x68, 0xa1,
+	0x4f, 0x20, 0xe3, 0x3f, 0x71, 0x95, 0x73, 0x97, 0xe0, 0xa3, 0xbe, 0x12, 0xbd, 0x90, 0x26, 0x67,
+	0x86, 0xd7, 0x1b, 0xd8, 0x3a, 0x29, 0xe7, 0xaf, 0x4b, 0x1b, 0x29, 0x9c, 0xa1, 0x0d, 0x0d, 0x7e,
+	0xb9, 0xe3, 0x6f, 0xba, 0x85, 0xf0, 0xa6, 0x3b, 0x45, 0xeb, 0x62, 0x08, 0xad, 0xd1, 0x27, 0x90,
+	0x1a, 0xd3, 0x77, 0x82, 0xf2, 0x0a, 0x73, 0xe4, 0x66, 0x9c, 0x77, 0x1b, 0xf6, 0xb0, 0x80, 0xb9,
+	0x9e, 0xfa, 0x04, 0xd2, 0x22, 0x51, 0x51, 0x38, 0x04, 0x50, 0x18, 0x5b, 0x6a, 0x95, 0x24, 0x06,
+	0x80, 0x87, 0xbb, 0x8d, 0x52, 0x02, 0x65, 0x21, 0x75, 0xbf, 0x83, 0x1b, 0x2d, 0x8e, 0x85, 0xb8,
+	0xd5, 0xe8, 0xec, 0x36, 0xda, 0x3b, 0xad, 0x92, 0x4c, 0x7f, 0xc1, 0xad, 0x2e, 0x3e, 0x2c, 0xa5,
+	0x28, 0xc0, 0xf2, 0x9c, 0x51, 0xc5, 0xdd, 0xce, 0x6e, 0x8b, 0xa3, 0xeb, 0xfe, 0x41, 0xa3, 0xd1,
+	0xda, 0xdf, 0xe7, 0xe8, 0xea, 0x9f, 0x4e, 0x13, 0xd4, 0x4e, 0xa3, 0xb6, 0xdb, 0x68, 0xed, 0x50,
+	0xe6, 0xc5, 0x0e, 0xab, 0xdd, 0xf6, 0xe3, 0x56, 0xe7, 0xa0, 0x5b, 0x92, 0xd5, 0xf7, 0xa0, 0xd0,
+	0xd0, 0xac, 0x01, 0x31, 0x2f, 0xb3, 0xf6, 0xd5, 0x53, 0x50, 0xf6, 0xc9, 0xc0, 0x21, 0x1e, 0x45,
+	0x2d, 0xfa, 0x32, 0xe0, 0xbf, 0x1f, 0xd0, 0xef, 0x2b, 0xdf, 0x44, 0x96, 0x21, 0xad, 0x13, 0x93,
+	0x50, 0x7d, 0x41, 0x0d, 0x84, 0xa8, 0xde, 0x83, 0xd2, 0x3e, 0xf1, 0xf8, 0xd0, 0xbe, 0xc3, 0x17,
+	0x79, 0x10, 0x61, 0xf1, 0x79, 0xc1, 0xe2, 0xd5, 0xb7, 0xa0, 0xf4, 0x20, 0x86, 0xb6, 0x4a, 0xe0,
+	0x5a, 0xa8, 0x5f, 0xc0, 0x42, 0x14, 0x97, 0xb5, 0x94, 0xa5, 0xe5, 0x87, 0x39, 0xa1, 0x2b, 0x34,
+	0xe6, 0xb8, 0x73, 0x13, 0x5e, 0xc6, 0x64, 0x68, 0x9f, 0x92, 0xe5, 0x1e, 0x95, 0xa0, 0xc8, 0x3b,
+	0x05, 0x77, 0x6a, 0x1d, 0x58, 0x09, 0x5a, 0x84, 0x87, 0xf7, 0x20, 0xcd, 0xc7, 0xf3, 0xb1, 0x34,
+	0x8e, 0x8b, 0xbe, 0x8a, 0xfa, 0x4b, 0x09, 0xf2, 0xfb, 0x44, 0x33, 0x89, 0xbe, 0x20, 0xb3, 0xab,
+	0x94, 0x8a, 0x59, 0x83, 0x60, 0x22, 0x4c, 0xa0, 0xf8, 0x38, 0x30, 0x46, 0x27, 0xc4, 0xf1, 0xc8,
+	0x19, 0x07, 0xfe, 0x3c, 0x0e, 0xb5, 0x84, 0xeb, 0x41, 0xbe, 0x62, 0x3d, 0xa4, 0xa2, 0xf5, 0xf0,
+	0x05, 0xac, 0x61, 0x32, 0x32, 0x8d, 0x81, 0xe6, 0xcd, 0x44, 0xf1, 0xd3, 0x99, 0x74, 0x6d, 0x2c,
+	0x8e, 0xc5, 0x74, 0xde, 0x7e, 0xd2, 0xd4, 0x35, 0x58, 0x0d, 0xb7, 0x07, 0x91, 0xff, 0x12, 0x5e,
+	0x99, 0x69, 0x17, 0xf1, 0xaf, 0xcf, 0xc6, 0x3f, 0xfe, 0x98, 0x41, 0x16, 0x4a, 0x50, 0x6c, 0xd8,
+	0x96, 0x47, 0xac, 0xe0, 0x31, 0xff, 0xb7, 0x12, 0xc8, 0x75, 0xd3, 0xee, 0x87, 0x76, 0x19, 0x29,
+	0xb2, 0xcb, 0x20, 0x90, 0xd9, 0x95, 0x08, 0x4d, 0x49, 0x12, 0xb3, 0x6f, 0x7a, 0x59, 0x32, 0x24,
+	0xba, 0xa1, 0xf5, 0xd8, 0x01, 0x95, 0x6f, 0x66, 0x59, 0xd6, 0xc2, 0x0e, 0x73, 0x1f, 0x43, 0x7a,
+	0xe0, 0x90, 0xcb, 0x27, 0x44, 0x28, 0x51, 0x57, 0xd8, 0xce, 0xe8, 0x8a, 0xf7, 0x3c, 0x21, 0xd1,
+	0x42, 0x70, 0xc8, 0x11, 0x71, 0x88, 0x35, 0x20, 0x3a, 0xdb, 0xeb, 0x32, 0x38, 0xd4, 0xa2, 0xfe,
+	0xbf, 0x04, 0x99, 0xa6, 0xfd, 0xad, 0x65, 0xda, 0x9a, 0x4e, 0xcf, 0xde, 0x0e, 0x39, 0xf2, 0xcf,
+	0xde, 0x0e, 0x61, 0xcf, 0x73, 0xf6, 0xd1, 0x91, 0x4b, 0x3c, 0x31, 0x17, 0x21, 0xd1, 0xaa, 0xf3,
+	0x6c, 0x4f, 0xe3, 0x44, 0x2e, 0x89, 0xb9, 0x70, 0xd5, 0xaa, 0x52, 0x7f, 0x2c, 0xc1, 0x4a, 0x10,
+	0xeb, 0xe9, 0x95, 0x77, 0xdf, 0xb4, 0xfb, 0xb1, 0xae, 0xbc, 0x69, 0x52, 0x30, 0xef, 0x4e, 0x79,
+	0x9a, 0x2e, 0xe6, 0xe5, 0x9f, 0x19, 0x16, 0x1e, 0x53, 0xfc, 0x20, 0xe0, 0xa9, 0x9a, 0x5a, 0x85,
+	0x97, 0xf7, 0x9c, 0xb1, 0x45, 0xa2, 0xf9, 0x47, 0xff, 0x02, 0x69, 0xdd, 0x99, 0xf4, 0x9c, 0xb1,
+	0xe5, 0x73, 0x5e, 0xdd, 0x99, 0xe0, 0xb1, 0xa5, 0x9a, 0xb0, 0x1a, 0xed, 0x1f, 0x00, 0x55, 0xda,
+	0x61, 0xb0, 0xa2, 0xc7, 0x9e, 0x85, 0xaf, 0xc0, 0x9e, 0x37, 0xfa, 0xb6, 0xc3, 0x91, 0x9b, 0xbd,
+	0x95, 0x09, 0x51, 0xbd, 0x03, 0xab, 0x62, 0xa0, 0x36, 0xcb, 0xb5, 0xef, 0x1e, 0x5d, 0x9b, 0xac,
+	0x0e, 0x79, 0xcc, 0xb2, 0xd8, 0x17, 0xd5, 0xbb, 0xf0, 0xca, 0x8c, 0xc6, 0xf4, 0x34, 0x3c, 0x47,
+	0xa5, 0x09, 0x88, 0xbe, 0x72, 0xcf, 0x44, 0x60, 0x5e, 0xe1, 0xcf, 0x29, 0x17, 0x55, 0x85, 0xbc,
+	0xb0, 0xd0, 0x38, 0x19, 0x5b, 0x4f, 0xe9, 0x02, 0xd1, 0x35, 0x4f, 0x63, 0xda, 0x79, 0xcc, 0xbe,
+	0xb7, 0xfe, 0x50, 0x82, 0x54, 0x97, 0xc6, 0x00, 0x1d, 0x82, 0xcc, 0x4e, 0xa9, 0x0b, 0x9f, 0x4c,
+	0x43, 0xff, 0xae, 0xa9, 0x6c, 0x2c, 0xef, 0x28, 0x26, 0xda, 0x86, 0x14, 0x7b, 0x34, 0x43, 0x0b,
+	0x55, 0xc2, 0xef, 0x6a, 0x95, 0xb5, 0xe7, 0x6a, 0xb8, 0x45, 0xff, 0x07, 0x84, 0xfe, 0x07, 0x52,
+	0xec, 0x71, 0x66, 0xb1, 0xa9, 0xf0, 0x3b, 0x57, 0xe5, 0x66, 0x8c, 0x9e, 0xc2, 0xd1, 0x5e, 0x70,
+	0xf9, 0xbd, 0x50, 0x29, 0xf2, 0xaa, 0x53, 0xb9, 0x15, 0xa7, 0xab, 0x18, 0xe0, 0x11, 0x28, 0xfc,
+	0xaa, 0x7a, 0xf1, 0x00, 0x91, 0xeb, 0xec, 0xb9, 0xb1, 0xd0, 0x20, 0x2d, 0xee, 0xd3, 0xd0, 0xad,
+	0x18, 0x97, 0x6e, 0xb1, 0xf2, 0x16, 0xbe, 0xa0, 0xbb, 0x23, 0xd1, 0x80, 0xf0, 0x2b, 0x9c, 0xc5,
+	0xfe, 0x46, 0x6e, 0x8e, 0x2a, 0xb7, 0xe2, 0x74, 0x15, 0x01, 0xe9, 0x43, 0x5a, 0xdc, 0xe6, 0x2c,
+	0x9e, 0x43, 0xf4, 0x12, 0xa8, 0x72, 0x3b, 0x56, 0x5f, 0x31, 0xc6, 0x21, 0xc8, 0xec, 0xda, 0xe3,
+	0xc6, 0xb2, 0x4b, 0x83, 0x58, 0x11, 0x8a, 0x5c, 0xc9, 0x7c, 0x0e, 0x32, 0x3d, 0x96, 0x2f, 0x59,
+	0x34, 0xd3, 0x83, 0x7b, 0xe5, 0x8d, 0x25, 0x1d, 0xd9, 0xa9, 0xfb, 0x8e, 0x84, 0x9e, 0x02, 0x4c,
+	0x0f, 0x8a, 0x28, 0xde, 0x31, 0x28, 0x18, 0xa4, 0x1a, 0xb7, 0xfb, 0xb4, 0x2a, 0x39, 0xf7, 0x5d,
+	0x9c, 0xe5, 0x08, 0x3f, 0x9e, 0x5b, 0x95, 0x4f, 0x20, 0x1b, 0x50, 0x53, 0xf4, 0xf6, 0xe2, 0x9d,
+	0x3f, 0xca, 0x41, 0xe7, 0x9a, 0x3c, 0x81, 0xec, 0x83, 0x78, 0x26, 0x67, 0x69, 0x6d, 0xe5, 0x9d,
+	0x98, 0xbd, 0x83, 0x7c, 0xe6, 0xc3, 0x54, 0x14, 0x6d, 0x2e, 0x3e, 0xe4, 0x3c, 0x47, 0x5a, 0xe7,
+	0x4e, 0xa1, 0x0f, 0x69, 0xde, 0xd1, 0x5d, 0x5c, 0xe7, 0x51, 0x8e, 0x55, 0xb9, 0x1d, 0xab, 0xaf,
+	0x70, 0xfe, 0x2b, 0x58, 0x99, 0x21, 0x81, 0x68, 0x6b, 0xb1, 0xff, 0x17, 0x31, 0xc6, 0xb9, 0x53,
+	0xf0, 0xa0, 0x10, 0xe1, 0x7b, 0xe8, 0x4e, 0x5c, 0x5a, 0x17, 0x4c, 0xe7, 0xee, 0x25, 0x34, 0xa6,
+	0x00, 0x21, 0x36, 0xb1, 0xc5, 0x81, 0x8b, 0xee, 0x95, 0x95, 0xdb, 0xb1, 0xfa, 0x8a, 0x31, 0xbe,
+	0x81, 0x7c, 0x98, 0x41, 0x2c, 0xce, 0xfa, 0x05, 0xdc, 0xa4, 0x72, 0x27, 0xbe, 0x82, 0x18, 0xd2,
+	0x83, 0x42, 0x84, 0x14, 0x2c, 0x0e, 0xe6, 0x45, 0x8c, 0xa3, 0x72, 0xf7, 0x12, 0x1a, 0x62, 0x54,
+	0x03, 0x72, 0x21, 0x5e, 0x81, 0xaa, 0x8b, 0xab, 0x63, 0x96, 0x80, 0x2c, 0xc6, 0xc5, 0x30, 0xd5,
+	0xb8, 0x23, 0xd5, 0x6f, 0x7f, 0x71, 0x33, 0xde, 0x1f, 0x8a, 0xff, 0xeb, 0xf4, 0xee, 0x7f, 0xbf,
+	0xd4, 0x57, 0x58, 0xb1, 0xbd, 0xfb, 0x8f, 0x01, 0x00, 0x01, 0x19, 0x22, 0xfb, 0x86, 0x2c, 0x00,
+	0x00,
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"time"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
 )
 
 func (c *Client) Apply(manifests []*api.Manifest, force bool) error {
@@ -19,3 +19,20 @@ func (c *Client) Apply(manifests []*api.Manifest, force bool) error {
 	}
 	return nil
 }
+
+// Restore applies the manifests to the node and removes the assemblies
+// that are only present in the previous manifests
+func (c *Client) Restore(manifests, previous []*api.Manifest) error {
+	if _, err := c.client.Apply(context.Background(), &api.ApplyRequest{
+		ManifestList: &api.ManifestList{
+			Manifests: manifests,
+			Updated:   time.Now(),
+		},
+		Previous: &api.ManifestList{
+			Manifests: previous,
+		},
+	}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// Cancel aborts the in progress apply on the node; the connected node if empty
+func (c *Client) Cancel(nodeID string) error {
+	if _, err := c.client.Cancel(context.Background(), &api.CancelRequest{
+		NodeID: nodeID,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// Content returns the blobs and partial downloads in the node content store
+func (c *Client) Content() (*api.ContentResponse, error) {
+	return c.client.Content(context.Background(), &api.ContentRequest{})
+}
+
+// PruneContent removes the unreferenced blobs from the node content store.
+// dryRun only returns the blobs that would be removed.
+func (c *Client) PruneContent(dryRun bool) (*api.PruneContentResponse, error) {
+	return c.client.PruneContent(context.Background(), &api.PruneContentRequest{
+		DryRun: dryRun,
+	})
+}
+
+// ContentImages returns the image digests that are fetched completely into the
+// node content store
+func (c *Client) ContentImages(digests []string) ([]string, error) {
+	resp, err := c.client.ContentImages(context.Background(), &api.ContentImagesRequest{
+		Digests: digests,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Digests, nil
+}
+
+// ReadContent streams the blob from the node content store starting at offset
+func (c *Client) ReadContent(dgst string, offset int64) (api.Terra_ReadContentClient, error) {
+	return c.client.ReadContent(context.Background(), &api.ReadContentRequest{
+		Digest: dgst,
+		Offset: offset,
+	})
+}
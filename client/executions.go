@@ -0,0 +1,16 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func (c *Client) Executions(req *api.ExecutionsRequest) ([]*api.Execution, error) {
+	resp, err := c.client.Executions(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Executions, nil
+}
@@ -0,0 +1,13 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func (c *Client) Health(assembly *api.Assembly) (*api.HealthResponse, error) {
+	return c.client.Health(context.Background(), &api.HealthRequest{
+		Assembly: assembly,
+	})
+}
@@ -0,0 +1,16 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func (c *Client) History() ([]*api.ManifestList, error) {
+	resp, err := c.client.History(context.Background(), &api.HistoryRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Revisions, nil
+}
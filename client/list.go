@@ -3,7 +3,7 @@ package client
 import (
 	"context"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
 )
 
 func (c *Client) List() (*api.ManifestList, error) {
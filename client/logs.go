@@ -0,0 +1,11 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func (c *Client) Logs(req *api.LogsRequest) (api.Terra_LogsClient, error) {
+	return c.client.Logs(context.Background(), req)
+}
@@ -3,11 +3,14 @@ package client
 import (
 	"context"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
 )
 
-func (c *Client) Nodes() ([]*api.Node, error) {
-	resp, err := c.client.Nodes(context.Background(), &api.NodesRequest{})
+// Nodes returns the cluster nodes matching the label selector; all nodes if empty
+func (c *Client) Nodes(selector string) ([]*api.Node, error) {
+	resp, err := c.client.Nodes(context.Background(), &api.NodesRequest{
+		Selector: selector,
+	})
 	if err != nil {
 		return nil, err
 	}
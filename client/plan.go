@@ -0,0 +1,20 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func (c *Client) Plan(ml *api.ManifestList, force, cluster bool) ([]*api.NodePlan, error) {
+	resp, err := c.client.Plan(context.Background(), &api.PlanRequest{
+		ManifestList: ml,
+		Force:        force,
+		Cluster:      cluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Nodes, nil
+}
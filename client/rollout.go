@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// RolloutOptions configure a rolling update of the cluster
+type RolloutOptions struct {
+	Force          bool
+	BatchSize      int
+	MaxUnavailable int
+	MaxFailures    int
+	NodeTimeout    time.Duration
+	// Canary updates and checks a subset of nodes before the rest of the cluster
+	Canary *api.Canary
+}
+
+func (c *Client) Rollout(manifests []*api.Manifest, opts *RolloutOptions) (api.Terra_RolloutClient, error) {
+	return c.client.Rollout(context.Background(), &api.RolloutRequest{
+		ManifestList: &api.ManifestList{
+			Manifests: manifests,
+			Updated:   time.Now(),
+		},
+		Force:          opts.Force,
+		BatchSize:      uint32(opts.BatchSize),
+		MaxUnavailable: uint32(opts.MaxUnavailable),
+		MaxFailures:    uint32(opts.MaxFailures),
+		NodeTimeout:    opts.NodeTimeout,
+		Canary:         opts.Canary,
+	})
+}
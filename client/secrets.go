@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// SetSecret stores the secret in the cluster
+func (c *Client) SetSecret(name string, value []byte) error {
+	if _, err := c.client.SetSecret(context.Background(), &api.SetSecretRequest{
+		Name:  name,
+		Value: value,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSecret returns the secret and its decrypted value
+func (c *Client) GetSecret(name string) (*api.GetSecretResponse, error) {
+	return c.client.GetSecret(context.Background(), &api.GetSecretRequest{
+		Name: name,
+	})
+}
+
+// RemoveSecret removes the secret from the cluster
+func (c *Client) RemoveSecret(name string) error {
+	if _, err := c.client.RemoveSecret(context.Background(), &api.RemoveSecretRequest{
+		Name: name,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Secrets returns the secrets stored on the node, including removed secrets
+func (c *Client) Secrets() ([]*api.Secret, error) {
+	resp, err := c.client.Secrets(context.Background(), &api.SecretsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Secrets, nil
+}
+
+// ReplicateSecret stores the sealed secret on the node
+func (c *Client) ReplicateSecret(secret *api.SealedSecret) error {
+	if _, err := c.client.ReplicateSecret(context.Background(), &api.ReplicateSecretRequest{
+		Secret: secret,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SealedSecrets returns the secrets stored on the node sealed with the cluster key
+func (c *Client) SealedSecrets() ([]*api.SealedSecret, error) {
+	resp, err := c.client.SealedSecrets(context.Background(), &api.SealedSecretsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Secrets, nil
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"time"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
 )
 
 func (c *Client) Update(manifests []*api.Manifest, force bool) error {
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/urfave/cli"
+)
+
+var applyOperationsCommand = cli.Command{
+	Name:  "apply",
+	Usage: "node apply operations",
+	Subcommands: []cli.Command{
+		cancelApplyCommand,
+	},
+}
+
+var cancelApplyCommand = cli.Command{
+	Name:  "cancel",
+	Usage: "cancel the in progress apply on a node",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node",
+			Usage: "node to cancel (connected node if empty)",
+		},
+	},
+	Action: cancelApply,
+}
+
+func cancelApply(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return c.Cancel(ctx.String("node"))
+}
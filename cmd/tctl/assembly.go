@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/urfave/cli"
+)
+
+var assemblyCommand = cli.Command{
+	Name:  "assembly",
+	Usage: "assembly operations",
+	Subcommands: []cli.Command{
+		runsCommand,
+	},
+}
+
+var runsCommand = cli.Command{
+	Name:  "runs",
+	Usage: "show recorded assembly executions",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node",
+			Usage: "only show executions on the node (all nodes if empty)",
+		},
+		cli.StringFlag{
+			Name:  "image",
+			Usage: "only show executions of the assembly image",
+		},
+		cli.IntFlag{
+			Name:  "limit, n",
+			Usage: "number of recent executions per node to show (0 for all)",
+			Value: 20,
+		},
+		cli.BoolFlag{
+			Name:  "output",
+			Usage: "show execution output",
+		},
+	},
+	Action: runs,
+}
+
+func runs(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	executions, err := c.Executions(&api.ExecutionsRequest{
+		NodeID: ctx.String("node"),
+		Image:  ctx.String("image"),
+		Limit:  uint32(ctx.Int("limit")),
+	})
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("output") {
+		for _, e := range executions {
+			fmt.Printf("==> %s %s %s #%d (%s, exit %d)\n", e.NodeID, e.Image, e.Entrypoint, e.ID, api.Execution_Result_name[int32(e.Result)], e.ExitCode)
+			if e.Error != "" {
+				fmt.Printf("error: %s\n", e.Error)
+			}
+			fmt.Println(e.Output)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "NODE\tID\tIMAGE\tENTRYPOINT\tTRIGGER\tSTARTED\tDURATION\tEXIT\tRESULT\tCPU\tPEAK MEMORY\tDIGEST\n")
+	for _, e := range executions {
+		cpu, memory := "", ""
+		if u := e.Usage; u != nil {
+			cpu = u.CPU.Round(time.Millisecond).String()
+			memory = humanBytes(u.MemoryPeak)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			e.NodeID,
+			e.ID,
+			e.Image,
+			e.Entrypoint,
+			api.Execution_Trigger_name[int32(e.Trigger)],
+			e.Started.Format(time.RFC3339),
+			e.Duration.Round(time.Millisecond),
+			e.ExitCode,
+			api.Execution_Result_name[int32(e.Result)],
+			cpu,
+			memory,
+			shortDigest(e.Digest),
+		)
+	}
+	w.Flush()
+
+	return nil
+}
+
+// humanBytes returns the byte count with a binary unit suffix
+func humanBytes(n uint64) string {
+	if n == 0 {
+		return ""
+	}
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
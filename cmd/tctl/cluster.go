@@ -3,10 +3,13 @@ package main
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/manifest"
 	"github.com/urfave/cli"
 )
 
@@ -15,13 +18,25 @@ var clusterCommand = cli.Command{
 	Usage: "cluster operations",
 	Subcommands: []cli.Command{
 		nodesCommand,
+		statusCommand,
 	},
 }
 
+var selectorFlag = cli.StringFlag{
+	Name:  "selector, l",
+	Usage: "only show nodes matching the label selector (e.g. \"env=prod,zone in (a,b),!gpu\")",
+}
+
 var nodesCommand = cli.Command{
-	Name:   "nodes",
-	Usage:  "list terra nodes",
-	Flags:  []cli.Flag{},
+	Name:  "nodes",
+	Usage: "list terra nodes",
+	Flags: []cli.Flag{
+		selectorFlag,
+		cli.BoolFlag{
+			Name:  "facts",
+			Usage: "show the collected terra.io/ fact labels",
+		},
+	},
 	Action: nodes,
 }
 
@@ -32,27 +47,86 @@ func nodes(ctx *cli.Context) error {
 	}
 	defer c.Close()
 
-	nodes, err := c.Nodes()
+	nodes, err := c.Nodes(ctx.String("selector"))
 	if err != nil {
 		return err
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
-	fmt.Fprintf(w, "ID\tADDRESS\tLABELS\tSTATUS\n")
+	fmt.Fprintf(w, "ID\tADDRESS\tARCH\tOS\tLABELS\tSTATUS\n")
 	for _, n := range nodes {
+		nodeLabels := n.GetLabels()
 		labels := []string{}
-		for k, v := range n.GetLabels() {
+		for k, v := range nodeLabels {
+			if strings.HasPrefix(k, manifest.FactLabelPrefix) && !ctx.Bool("facts") {
+				continue
+			}
 			labels = append(labels, fmt.Sprintf("%s=%s", k, v))
 		}
+		sort.Strings(labels)
 
 		state := api.NodeStatus_Status_name[int32(n.GetStatus().Status)]
 		status := fmt.Sprintf("%s", state)
 		if desc := n.GetStatus().GetDescription(); desc != "" {
 			status = fmt.Sprintf("%s (%s)", state, desc)
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", n.GetID(), n.GetAddress(), strings.Join(labels, ","), status)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			n.GetID(),
+			n.GetAddress(),
+			nodeLabels[manifest.FactLabelPrefix+"arch"],
+			nodeLabels[manifest.FactLabelPrefix+"os-release"],
+			strings.Join(labels, ","),
+			status,
+		)
+	}
+	w.Flush()
+
+	return nil
+}
+
+var statusCommand = cli.Command{
+	Name:  "status",
+	Usage: "show assembly status for terra nodes",
+	Flags: []cli.Flag{
+		selectorFlag,
+	},
+	Action: status,
+}
+
+func status(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	nodes, err := c.Nodes(ctx.String("selector"))
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "NODE\tIMAGE\tDIGEST\tSTATUS\tATTEMPTS\tNEXT RETRY\tRULE\tDESCRIPTION\n")
+	for _, n := range nodes {
+		for _, a := range n.GetStatus().GetAssemblies() {
+			state := api.AssemblyStatus_Status_name[int32(a.Status)]
+			nextRetry := ""
+			if a.NextRetry != nil {
+				nextRetry = a.NextRetry.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n", n.GetID(), a.Image, shortDigest(a.Digest), state, a.Attempts, nextRetry, a.Rule, a.Description)
+		}
 	}
 	w.Flush()
 
 	return nil
 }
+
+// shortDigest returns the truncated digest for display
+func shortDigest(dgst string) string {
+	parts := strings.SplitN(dgst, ":", 2)
+	if len(parts) != 2 || len(parts[1]) < 12 {
+		return dgst
+	}
+	return parts[1][:12]
+}
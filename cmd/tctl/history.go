@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	"github.com/stellarproject/terra/manifest"
+	"github.com/urfave/cli"
+)
+
+var historyCommand = cli.Command{
+	Name:   "history",
+	Usage:  "list manifest list revisions",
+	Flags:  []cli.Flag{},
+	Action: history,
+}
+
+func history(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	revisions, err := c.History()
+	if err != nil {
+		return err
+	}
+
+	current, err := c.List()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "REVISION\tUPDATED\tMANIFESTS\tASSEMBLIES\tCURRENT\n")
+	for _, ml := range revisions {
+		assemblies := 0
+		for _, m := range ml.Manifests {
+			assemblies += len(m.Assemblies)
+		}
+		cur := ""
+		if current != nil && current.Revision == ml.Revision {
+			cur = "*"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%s\n", ml.Revision, ml.Updated.Format(time.RFC3339), len(ml.Manifests), assemblies, cur)
+	}
+	w.Flush()
+
+	return nil
+}
+
+var diffCommand = cli.Command{
+	Name:      "diff",
+	Usage:     "show the assembly changes between two manifest list revisions",
+	ArgsUsage: "<REVISION> <REVISION>",
+	Flags:     []cli.Flag{},
+	Action:    diff,
+}
+
+func diff(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	old, err := getRevision(c, ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	new, err := getRevision(c, ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	for _, change := range manifest.Diff(old, new) {
+		fmt.Println(change)
+	}
+
+	return nil
+}
+
+var rollbackCommand = cli.Command{
+	Name:      "rollback",
+	Usage:     "publish a previous manifest list revision as the current revision",
+	ArgsUsage: "<REVISION>",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "force update manifest list",
+		},
+	},
+	Action: rollback,
+}
+
+func rollback(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ml, err := getRevision(c, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	return c.Update(ml.Manifests, ctx.Bool("force"))
+}
+
+// getRevision returns the manifest list for the revision from the cluster history
+func getRevision(c *client.Client, rev string) (*api.ManifestList, error) {
+	revision, err := strconv.ParseUint(rev, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid revision %s", rev)
+	}
+	revisions, err := c.History()
+	if err != nil {
+		return nil, err
+	}
+	for _, ml := range revisions {
+		if ml.Revision == revision {
+			return ml, nil
+		}
+	}
+	return nil, errors.Errorf("revision %d not found", revision)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/urfave/cli"
+)
+
+var logsCommand = cli.Command{
+	Name:  "logs",
+	Usage: "show assembly execution output",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "follow, f",
+			Usage: "follow output",
+		},
+		cli.StringFlag{
+			Name:  "image",
+			Usage: "only show output for the assembly image",
+		},
+		cli.IntFlag{
+			Name:  "tail",
+			Usage: "number of recent lines per execution to show (0 for all)",
+		},
+		cli.BoolFlag{
+			Name:  "local",
+			Usage: "only show output from the connected node",
+		},
+	},
+	Action: logs,
+}
+
+func logs(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	stream, err := c.Logs(&api.LogsRequest{
+		Follow:  ctx.Bool("follow"),
+		Image:   ctx.String("image"),
+		Tail:    uint32(ctx.Int("tail")),
+		Cluster: !ctx.Bool("local"),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		fmt.Printf("[%s] %s %s: %s\n", entry.NodeID, entry.Image, entry.Entrypoint, entry.Line)
+	}
+}
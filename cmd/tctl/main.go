@@ -45,6 +45,11 @@ func main() {
 	app.Commands = []cli.Command{
 		clusterCommand,
 		manifestCommand,
+		logsCommand,
+		assemblyCommand,
+		applyOperationsCommand,
+		secretCommand,
+		nodeCommand,
 	}
 	app.Before = func(ctx *cli.Context) error {
 		if ctx.Bool("debug") {
@@ -55,7 +60,7 @@ func main() {
 	}
 
 	if err := app.Run(os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, err.Error()+"\n")
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 }
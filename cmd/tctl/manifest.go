@@ -2,16 +2,31 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"html/template"
 	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
-	api "github.com/stellarproject/nebula/terra/v1"
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	"github.com/stellarproject/terra/manifest"
 	"github.com/urfave/cli"
 )
 
 const (
-	listTemplate = `{{ range .Manifests }}- NodeID: {{ .NodeID }}{{ if .Labels }}
+	listTemplate = `Revision: {{ .Revision }}
+{{ range .Manifests }}- NodeID: {{ .NodeID }}{{ if .NodeIDs }}
+  NodeIDs:{{ range .NodeIDs }}
+    - {{ . }}{{ end }}{{ end }}{{ if .Exclude }}
+  Exclude:{{ range .Exclude }}
+    - {{ . }}{{ end }}{{ end }}{{ if .Selector }}
+  Selector: {{ .Selector }}{{ end }}{{ if .Labels }}
   Labels: {{ range $k, $v := .Labels }}
+    - {{ $k }}={{ $v }}{{ end }}{{ end }}{{ if .Variables }}
+  Variables: {{ range $k, $v := .Variables }}
     - {{ $k }}={{ $v }}{{ end }}{{ end }}
   Assemblies:
 {{ range .Assemblies }}    - Image: {{ .Image }}{{ if .Requires }}
@@ -29,13 +44,32 @@ var manifestCommand = cli.Command{
 		listCommand,
 		applyCommand,
 		updateCommand,
+		graphCommand,
+		planCommand,
+		historyCommand,
+		diffCommand,
+		rollbackCommand,
+		migrateCommand,
+		validateCommand,
+		renderCommand,
 	},
 }
 
+var overlayFlag = cli.StringSliceFlag{
+	Name:  "overlay",
+	Usage: "overlay file composed after the manifest list (may be repeated)",
+}
+
 var listCommand = cli.Command{
-	Name:   "list",
-	Usage:  "list terra assemblies",
-	Flags:  []cli.Flag{},
+	Name:  "list",
+	Usage: "list terra assemblies",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "output format (text, json, yaml, toml); json, yaml and toml output can be applied",
+			Value: "text",
+		},
+	},
 	Action: list,
 }
 
@@ -55,6 +89,19 @@ func list(ctx *cli.Context) error {
 		return nil
 	}
 
+	if output := ctx.String("output"); output != "text" {
+		format, err := manifest.ParseFormat(output)
+		if err != nil {
+			return err
+		}
+		data, err := manifest.Encode(manifestList, format)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
 	t := template.New("list")
 	tmpl, err := t.Parse(listTemplate)
 	if err != nil {
@@ -73,6 +120,7 @@ var applyCommand = cli.Command{
 	Usage:     "apply terra manifests directly to the node",
 	ArgsUsage: "[MANIFEST_LIST]",
 	Flags: []cli.Flag{
+		overlayFlag,
 		cli.BoolFlag{
 			Name:  "force",
 			Usage: "force apply manifest list",
@@ -97,14 +145,10 @@ func apply(ctx *cli.Context) error {
 	}
 	defer c.Close()
 
-	var manifestList *api.ManifestList
-	f, err := os.Open(manifestListPath)
+	manifestList, err := loadManifestList(ctx, manifestListPath)
 	if err != nil {
 		return err
 	}
-	if err := json.NewDecoder(f).Decode(&manifestList); err != nil {
-		return err
-	}
 
 	if err := c.Apply(manifestList.Manifests, force); err != nil {
 		return err
@@ -118,9 +162,65 @@ var updateCommand = cli.Command{
 	Usage:     "update terra manifest list for the cluster",
 	ArgsUsage: "[MANIFEST_LIST]",
 	Flags: []cli.Flag{
+		overlayFlag,
 		cli.BoolFlag{
 			Name:  "force",
-			Usage: "force update manifest list",
+			Usage: "force update manifest list (requires --strategy rolling or --canary)",
+		},
+		cli.StringFlag{
+			Name:  "strategy",
+			Usage: "update strategy (all, rolling)",
+			Value: "all",
+		},
+		cli.IntFlag{
+			Name:  "batch-size",
+			Usage: "number of nodes to update per batch for rolling updates",
+			Value: 1,
+		},
+		cli.IntFlag{
+			Name:  "max-unavailable",
+			Usage: "maximum number of updating or failed nodes for rolling updates (default: batch size)",
+		},
+		cli.IntFlag{
+			Name:  "max-failures",
+			Usage: "number of failed nodes tolerated before a rolling update is halted",
+		},
+		cli.DurationFlag{
+			Name:  "node-timeout",
+			Usage: "time to wait for each node to update for rolling updates",
+			Value: 10 * time.Minute,
+		},
+		cli.BoolFlag{
+			Name:  "canary",
+			Usage: "update canary nodes before the rest of the cluster (implies rolling strategy)",
+		},
+		cli.StringFlag{
+			Name:  "canary-selector",
+			Usage: "label selector for the canary nodes",
+		},
+		cli.StringSliceFlag{
+			Name:  "canary-label",
+			Usage: "label(s) selecting the canary nodes (deprecated; use --canary-selector)",
+			Value: &cli.StringSlice{},
+		},
+		cli.IntFlag{
+			Name:  "canary-percent",
+			Usage: "percent of nodes to use as canaries when no canary selector is specified",
+			Value: 10,
+		},
+		cli.DurationFlag{
+			Name:  "bake-time",
+			Usage: "time canary nodes must remain healthy before promotion",
+			Value: 5 * time.Minute,
+		},
+		cli.StringFlag{
+			Name:  "health-image",
+			Usage: "assembly image with a health executable to run on canary nodes",
+		},
+		cli.DurationFlag{
+			Name:  "health-interval",
+			Usage: "time between canary health checks",
+			Value: 10 * time.Second,
 		},
 	},
 	Action: update,
@@ -142,18 +242,208 @@ func update(ctx *cli.Context) error {
 	}
 	defer c.Close()
 
+	manifestList, err := loadManifestList(ctx, manifestListPath)
+	if err != nil {
+		return err
+	}
+
+	strategy := ctx.String("strategy")
+	var canary *api.Canary
+	if ctx.Bool("canary") {
+		strategy = "rolling"
+		c, err := getCanary(ctx)
+		if err != nil {
+			return err
+		}
+		canary = c
+	}
+
+	switch strategy {
+	case "all":
+		// peers apply an updated manifest list as it is replicated so a forced
+		// update of every node is only supported through a rollout
+		if force {
+			return errors.New("--force requires --strategy rolling or --canary")
+		}
+		if err := c.Update(manifestList.Manifests, force); err != nil {
+			return err
+		}
+	case "rolling":
+		return rollout(c, manifestList, &client.RolloutOptions{
+			Force:          force,
+			BatchSize:      ctx.Int("batch-size"),
+			MaxUnavailable: ctx.Int("max-unavailable"),
+			MaxFailures:    ctx.Int("max-failures"),
+			NodeTimeout:    ctx.Duration("node-timeout"),
+			Canary:         canary,
+		})
+	default:
+		return errors.Errorf("unknown update strategy %s", strategy)
+	}
+
+	return nil
+}
+
+var graphCommand = cli.Command{
+	Name:      "graph",
+	Usage:     "show the assembly apply order for each node",
+	ArgsUsage: "[MANIFEST_LIST]",
+	Flags: []cli.Flag{
+		overlayFlag,
+		cli.StringFlag{
+			Name:  "node",
+			Usage: "only show the apply order for the node id",
+		},
+	},
+	Action: graph,
+}
+
+func graph(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// use the manifest list from the file if specified
 	var manifestList *api.ManifestList
-	f, err := os.Open(manifestListPath)
+	if manifestListPath := ctx.Args().First(); manifestListPath != "" {
+		ml, err := loadManifestList(ctx, manifestListPath)
+		if err != nil {
+			return err
+		}
+		manifestList = ml
+	} else {
+		ml, err := c.List()
+		if err != nil {
+			return err
+		}
+		manifestList = ml
+	}
+
+	if manifestList == nil {
+		return nil
+	}
+
+	nodes, err := c.Nodes("")
+	if err != nil {
+		return err
+	}
+
+	nodeID := ctx.String("node")
+	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "NODE\tORDER\tIMAGE\tREQUIRES\n")
+	for _, n := range nodes {
+		if nodeID != "" && n.ID != nodeID {
+			continue
+		}
+		g := manifest.NewGraph(manifest.NodeAssemblies(manifestList, n.ID, n.Labels))
+		assemblies, err := g.Sort()
+		if err != nil {
+			return errors.Wrapf(err, "node %s", n.ID)
+		}
+		for i, a := range assemblies {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", n.ID, i+1, a.Image, strings.Join(g.Requires(a.Image), ","))
+		}
+	}
+	w.Flush()
+
+	return nil
+}
+
+var migrateCommand = cli.Command{
+	Name:      "migrate",
+	Usage:     "print the manifest list with the deprecated manifest labels converted to selectors",
+	ArgsUsage: "[MANIFEST_LIST]",
+	Action:    migrate,
+}
+
+func migrate(ctx *cli.Context) error {
+	manifestListPath := ctx.Args().First()
+	if manifestListPath == "" {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	manifestList, err := loadManifestList(ctx, manifestListPath)
 	if err != nil {
 		return err
 	}
-	if err := json.NewDecoder(f).Decode(&manifestList); err != nil {
+	migrated := manifest.Migrate(manifestList)
+	if len(migrated.Manifests) != len(manifestList.Manifests) {
+		fmt.Fprintln(os.Stderr, "manifests with a node id or several labels were split as the labels of a manifest are alternatives")
+	}
+	data, err := json.MarshalIndent(migrated, "", "  ")
+	if err != nil {
 		return err
 	}
+	fmt.Println(string(data))
+	return nil
+}
 
-	if err := c.Update(manifestList.Manifests, force); err != nil {
+var planCommand = cli.Command{
+	Name:      "plan",
+	Usage:     "show what each node would do to apply a manifest list",
+	ArgsUsage: "[MANIFEST_LIST]",
+	Flags: []cli.Flag{
+		overlayFlag,
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "plan a forced update",
+		},
+		cli.BoolFlag{
+			Name:  "local",
+			Usage: "only plan the connected node",
+		},
+	},
+	Action: plan,
+}
+
+func plan(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	manifestList, err := loadManifestList(ctx, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	nodes, err := c.Plan(manifestList, ctx.Bool("force"), !ctx.Bool("local"))
+	if err != nil {
 		return err
 	}
 
+	w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "NODE\tACTION\tIMAGE\tAPPLIED\tDIGEST\tREASON\tRULE\n")
+	for _, n := range nodes {
+		if n.Error != "" {
+			fmt.Fprintf(w, "%s\tERROR\t\t\t\t%s\t\n", n.NodeID, n.Error)
+			continue
+		}
+		for _, a := range n.Assemblies {
+			action := api.PlannedAssembly_Action_name[int32(a.Action)]
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", n.NodeID, action, a.Image, shortDigest(a.AppliedDigest), shortDigest(a.Digest), a.Reason, a.Rule)
+		}
+	}
+	w.Flush()
+
 	return nil
 }
+
+// loadManifestList composes and validates the manifest list from the file or
+// directory and the --overlay files.  warnings are printed to stderr.
+func loadManifestList(ctx *cli.Context, path string) (*api.ManifestList, error) {
+	manifestList, errs := manifest.Compose(path, ctx.StringSlice("overlay")...)
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	for _, e := range errs {
+		fmt.Fprintln(os.Stderr, e)
+	}
+	return manifestList, nil
+}
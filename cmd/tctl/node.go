@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+var nodeCommand = cli.Command{
+	Name:  "node",
+	Usage: "operations on the connected node",
+	Subcommands: []cli.Command{
+		cacheCommand,
+	},
+}
+
+var cacheCommand = cli.Command{
+	Name:  "cache",
+	Usage: "manage the image content cache",
+	Subcommands: []cli.Command{
+		listCacheCommand,
+		pruneCacheCommand,
+	},
+}
+
+var listCacheCommand = cli.Command{
+	Name:   "ls",
+	Usage:  "list the cached image blobs and partial downloads",
+	Action: listCache,
+}
+
+func listCache(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	resp, err := c.Content()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "DIGEST\tSIZE\tTYPE\tCREATED\tIN USE\tIMAGES\n")
+	var total, unused uint64
+	for _, b := range resp.Blobs {
+		total += uint64(b.Size_)
+		inUse := "yes"
+		if !b.Referenced {
+			unused += uint64(b.Size_)
+			inUse = "no"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			b.Digest,
+			humanBytes(uint64(b.Size_)),
+			b.MediaType,
+			b.Created.Format(time.RFC3339),
+			inUse,
+			strings.Join(b.Images, ","),
+		)
+	}
+	w.Flush()
+	fmt.Printf("\n%d blobs, %s total, %s reclaimable\n", len(resp.Blobs), totalBytes(total), totalBytes(unused))
+
+	if len(resp.Downloads) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "DOWNLOAD\tPROGRESS\tUPDATED\n")
+		for _, d := range resp.Downloads {
+			fmt.Fprintf(w, "%s\t%s/%s\t%s\n",
+				d.Ref,
+				humanBytes(uint64(d.Offset)),
+				humanBytes(uint64(d.Total)),
+				d.Updated.Format(time.RFC3339),
+			)
+		}
+		w.Flush()
+	}
+
+	return nil
+}
+
+var pruneCacheCommand = cli.Command{
+	Name:  "prune",
+	Usage: "remove the blobs not referenced by a current or recent revision",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run, n",
+			Usage: "only print the blobs that would be removed",
+		},
+	},
+	Action: pruneCache,
+}
+
+func pruneCache(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	resp, err := c.PruneContent(ctx.Bool("dry-run"))
+	if err != nil {
+		return err
+	}
+
+	var reclaimed uint64
+	for _, b := range resp.Removed {
+		reclaimed += uint64(b.Size_)
+		fmt.Println(b.Digest)
+	}
+	for _, ref := range resp.Aborted {
+		fmt.Println(ref)
+	}
+	verb := "removed"
+	if ctx.Bool("dry-run") {
+		verb = "would remove"
+	}
+	fmt.Printf("%s %d blobs (%s) and %d downloads\n", verb, len(resp.Removed), totalBytes(reclaimed), len(resp.Aborted))
+	return nil
+}
+
+// totalBytes returns the byte count with a binary unit suffix, including zero
+func totalBytes(n uint64) string {
+	if n == 0 {
+		return "0B"
+	}
+	return humanBytes(n)
+}
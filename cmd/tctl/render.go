@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+
+	"github.com/stellarproject/terra/manifest"
+	"github.com/urfave/cli"
+)
+
+var renderCommand = cli.Command{
+	Name:      "render",
+	Usage:     "print the manifest list composed from files, includes, variables and overlays",
+	ArgsUsage: "MANIFEST_LIST|DIR",
+	Flags: []cli.Flag{
+		overlayFlag,
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "output format (json, yaml, toml)",
+			Value: "json",
+		},
+	},
+	Action: render,
+}
+
+func render(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if path == "" {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	format, err := manifest.ParseFormat(ctx.String("output"))
+	if err != nil {
+		return err
+	}
+	manifestList, err := loadManifestList(ctx, path)
+	if err != nil {
+		return err
+	}
+	data, err := manifest.Encode(manifestList, format)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
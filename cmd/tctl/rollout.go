@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/client"
+	"github.com/stellarproject/terra/manifest"
+	"github.com/urfave/cli"
+)
+
+// rollout starts a rolling update and prints the progress as it is received
+func rollout(c *client.Client, manifestList *api.ManifestList, opts *client.RolloutOptions) error {
+	stream, err := c.Rollout(manifestList.Manifests, opts)
+	if err != nil {
+		return err
+	}
+
+	// events are printed as they arrive so columns are fixed width
+	format := "%-10s %-6v %-20s %-16s %s\n"
+	fmt.Printf(format, "TIME", "BATCH", "NODE", "EVENT", "DESCRIPTION")
+	for {
+		evt, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		t := api.RolloutEvent_Type_name[int32(evt.Type)]
+		fmt.Printf(format, evt.Timestamp.Format("15:04:05"), evt.Batch, evt.NodeID, t, evt.Description)
+	}
+}
+
+// getCanary returns the canary configuration from the update flags.  canary
+// labels are added to the selector as key=value terms.
+func getCanary(ctx *cli.Context) (*api.Canary, error) {
+	terms := []string{}
+	if s := ctx.String("canary-selector"); s != "" {
+		terms = append(terms, s)
+	}
+	for _, kv := range ctx.StringSlice("canary-label") {
+		if !strings.Contains(kv, "=") {
+			return nil, errors.Errorf("invalid canary label %s; expected key=value", kv)
+		}
+		terms = append(terms, kv)
+	}
+	selector := strings.Join(terms, ",")
+	if _, err := manifest.ParseSelector(selector); err != nil {
+		return nil, err
+	}
+	canary := &api.Canary{
+		Selector:       selector,
+		Percent:        uint32(ctx.Int("canary-percent")),
+		BakeTime:       ctx.Duration("bake-time"),
+		HealthInterval: ctx.Duration("health-interval"),
+	}
+	if image := ctx.String("health-image"); image != "" {
+		canary.Health = &api.Assembly{Image: image}
+	}
+	return canary, nil
+}
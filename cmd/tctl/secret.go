@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var secretCommand = cli.Command{
+	Name:  "secret",
+	Usage: "manage cluster secrets referenced from assembly parameters as secret:<name>",
+	Subcommands: []cli.Command{
+		setSecretCommand,
+		getSecretCommand,
+		removeSecretCommand,
+		listSecretsCommand,
+	},
+}
+
+var setSecretCommand = cli.Command{
+	Name:      "set",
+	Usage:     "set a secret from the value argument, a file or stdin",
+	ArgsUsage: "<name> [value]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "read the value from the file (\"-\" for stdin)",
+		},
+	},
+	Action: setSecret,
+}
+
+func setSecret(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	var value []byte
+	switch file := ctx.String("file"); {
+	case file == "-" || (file == "" && len(ctx.Args()) < 2):
+		v, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		value = v
+	case file != "":
+		v, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		value = v
+	default:
+		value = []byte(ctx.Args().Get(1))
+	}
+
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return c.SetSecret(name, value)
+}
+
+var getSecretCommand = cli.Command{
+	Name:      "get",
+	Usage:     "print the secret value",
+	ArgsUsage: "<name>",
+	Action:    getSecret,
+}
+
+func getSecret(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	resp, err := c.GetSecret(name)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(resp.Value)
+	return err
+}
+
+var removeSecretCommand = cli.Command{
+	Name:      "rm",
+	Usage:     "remove a secret",
+	ArgsUsage: "<name>",
+	Action:    removeSecret,
+}
+
+func removeSecret(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	for _, name := range ctx.Args() {
+		if err := c.RemoveSecret(name); err != nil {
+			return errors.Wrapf(err, "error removing secret %s", name)
+		}
+	}
+	return nil
+}
+
+var listSecretsCommand = cli.Command{
+	Name:   "ls",
+	Usage:  "list secret names",
+	Action: listSecrets,
+}
+
+func listSecrets(ctx *cli.Context) error {
+	c, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	secrets, err := c.Secrets()
+	if err != nil {
+		return err
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "NAME\tUPDATED\n")
+	for _, s := range secrets {
+		if s.Deleted {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\n", s.Name, s.Updated.Format(time.RFC3339))
+	}
+	w.Flush()
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/stellarproject/terra/manifest"
+	"github.com/urfave/cli"
+)
+
+var validateCommand = cli.Command{
+	Name:      "validate",
+	Usage:     "validate manifest lists without contacting the cluster",
+	ArgsUsage: "MANIFEST_LIST [MANIFEST_LIST...]",
+	Flags: []cli.Flag{
+		overlayFlag,
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "output format (text, json)",
+			Value: "text",
+		},
+	},
+	Action: validate,
+}
+
+func validate(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		cli.ShowSubcommandHelp(ctx)
+		return nil
+	}
+
+	errs := manifest.ValidationErrors{}
+	for _, path := range ctx.Args() {
+		_, fileErrs := manifest.Compose(path, ctx.StringSlice("overlay")...)
+		errs = append(errs, fileErrs...)
+	}
+
+	switch ctx.String("output") {
+	case "text":
+		for _, e := range errs {
+			fmt.Println(e)
+		}
+	case "json":
+		data, err := json.MarshalIndent(errs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	default:
+		return errors.Errorf("unsupported output format %q", ctx.String("output"))
+	}
+
+	if errs.Err() != nil {
+		return errors.New("manifest validation failed")
+	}
+	return nil
+}
@@ -1,15 +1,20 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stellarproject/terra/agent"
+	api "github.com/stellarproject/terra/api/v1"
+	"github.com/stellarproject/terra/manifest"
 	"github.com/stellarproject/terra/version"
 	"github.com/urfave/cli"
 )
@@ -20,6 +25,14 @@ const (
 )
 
 func main() {
+	// entrypoints with a read only host view are started through the agent binary
+	if agent.ExecInitRequested() {
+		if err := agent.ExecInit(); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
 	app := cli.NewApp()
 	app.Name = version.Name
 	app.Version = version.BuildVersion()
@@ -91,6 +104,79 @@ func main() {
 			Usage: "terra agent data directory",
 			Value: "/var/lib/terra",
 		},
+		cli.IntFlag{
+			Name:  "revision-history",
+			Usage: "number of manifest list revisions to keep",
+			Value: 10,
+		},
+		cli.IntFlag{
+			Name:  "execution-history",
+			Usage: "number of assembly executions to keep",
+			Value: 1000,
+		},
+		cli.DurationFlag{
+			Name:  "reconcile-interval",
+			Usage: "interval to check applied assemblies for drift (0 to disable)",
+			Value: 5 * time.Minute,
+		},
+		cli.DurationFlag{
+			Name:  "facts-interval",
+			Usage: "interval to collect node facts published as terra.io/ labels (0 to only collect at startup)",
+			Value: 10 * time.Minute,
+		},
+		cli.StringFlag{
+			Name:  "drift-policy",
+			Usage: "action for drifted assemblies (report, reinstall)",
+			Value: agent.DriftPolicyReport,
+		},
+		cli.DurationFlag{
+			Name:  "assembly-timeout",
+			Usage: "default maximum duration of an assembly entrypoint (0 for no timeout)",
+		},
+		cli.DurationFlag{
+			Name:  "assembly-stop-timeout",
+			Usage: "time to wait after SIGTERM before killing a stopped assembly entrypoint",
+			Value: 10 * time.Second,
+		},
+		cli.StringFlag{
+			Name:  "cgroup-slice",
+			Usage: "delegated cgroup v2 group for assembly executions relative to the cgroup root (disabled if empty)",
+		},
+		cli.StringFlag{
+			Name:  "cpu-limit",
+			Usage: "default number of CPUs for assembly executions",
+		},
+		cli.StringFlag{
+			Name:  "memory-limit",
+			Usage: "default memory limit for assembly executions (e.g. 512M)",
+		},
+		cli.Uint64Flag{
+			Name:  "pids-limit",
+			Usage: "default maximum number of processes for assembly executions",
+		},
+		cli.StringSliceFlag{
+			Name:  "io-limit",
+			Usage: "default cgroup io.max entries for assembly executions (e.g. \"8:0 wbps=1048576\")",
+			Value: &cli.StringSlice{},
+		},
+		cli.StringFlag{
+			Name:  "secret-key-file",
+			Usage: "file with the hex encoded 32 byte cluster key encrypting secrets (secrets disabled if empty)",
+		},
+		cli.IntFlag{
+			Name:  "apply-concurrency",
+			Usage: "maximum number of independent assemblies to apply at once",
+			Value: 1,
+		},
+		cli.IntFlag{
+			Name:  "fetch-concurrency",
+			Usage: "maximum number of image blobs to download at once",
+			Value: 3,
+		},
+		cli.BoolFlag{
+			Name:  "disable-peer-fetch",
+			Usage: "only fetch image blobs from the registry instead of peers that have the image",
+		},
 	}
 	app.Before = func(ctx *cli.Context) error {
 		if ctx.Bool("debug") {
@@ -114,7 +200,7 @@ func main() {
 	app.Action = agentAction
 
 	if err := app.Run(os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, err.Error()+"\n")
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 }
@@ -124,6 +210,21 @@ func agentAction(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	switch policy := ctx.String("drift-policy"); policy {
+	case agent.DriftPolicyReport, agent.DriftPolicyReinstall:
+	default:
+		return fmt.Errorf("unknown drift policy %q", policy)
+	}
+	secretKey, err := loadSecretKey(ctx.String("secret-key-file"))
+	if err != nil {
+		return err
+	}
+	resources := &api.ResourceLimits{
+		CPU:    ctx.String("cpu-limit"),
+		Memory: ctx.String("memory-limit"),
+		Pids:   ctx.Uint64("pids-limit"),
+		IO:     ctx.StringSlice("io-limit"),
+	}
 	cfg := &agent.AgentConfig{
 		NodeID:                ctx.String("node-id"),
 		GRPCAddress:           ctx.String("grpc-address"),
@@ -131,6 +232,19 @@ func agentAction(ctx *cli.Context) error {
 		AdvertiseAddress:      ctx.String("advertise-address"),
 		ConnectionType:        ctx.String("connection-type"),
 		DataDir:               ctx.String("data-dir"),
+		ApplyConcurrency:      ctx.Int("apply-concurrency"),
+		FetchConcurrency:      ctx.Int("fetch-concurrency"),
+		DisablePeerFetch:      ctx.Bool("disable-peer-fetch"),
+		RevisionHistory:       ctx.Int("revision-history"),
+		ExecutionHistory:      ctx.Int("execution-history"),
+		ReconcileInterval:     ctx.Duration("reconcile-interval"),
+		FactsInterval:         ctx.Duration("facts-interval"),
+		DriftPolicy:           ctx.String("drift-policy"),
+		AssemblyTimeout:       ctx.Duration("assembly-timeout"),
+		AssemblyStopTimeout:   ctx.Duration("assembly-stop-timeout"),
+		CgroupSlice:           ctx.String("cgroup-slice"),
+		Resources:             resources,
+		SecretKey:             secretKey,
 		Peers:                 ctx.StringSlice("peer"),
 		Labels:                labels,
 		TLSServerCertificate:  ctx.String("tls-cert"),
@@ -161,7 +275,7 @@ func agentAction(ctx *cli.Context) error {
 		}
 	}()
 
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 	doneCh := make(chan bool, 1)
 	go func() {
@@ -190,6 +304,26 @@ func agentAction(ctx *cli.Context) error {
 	return nil
 }
 
+// loadSecretKey returns the hex encoded cluster key from the file or nil if
+// no file is specified
+func loadSecretKey(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret key in %s: %s", path, err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("secret key in %s must be 32 bytes; got %d", path, len(key))
+	}
+	return key, nil
+}
+
 func getIP(ctx *cli.Context) string {
 	ip := "127.0.0.1"
 	devName := ctx.String("nic")
@@ -246,6 +380,9 @@ func getLabels(lbls []string) (map[string]string, error) {
 	for _, kv := range lbls {
 		parts := strings.SplitN(kv, "=", 2)
 		key := parts[0]
+		if strings.HasPrefix(key, manifest.FactLabelPrefix) {
+			return nil, fmt.Errorf("label prefix %s is reserved for node facts", manifest.FactLabelPrefix)
+		}
 
 		if len(parts) == 0 {
 			labels[key] = ""
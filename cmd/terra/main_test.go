@@ -55,3 +55,9 @@ func TestParseLabels(t *testing.T) {
 		}
 	}
 }
+
+func TestParseLabelsReserved(t *testing.T) {
+	if _, err := getLabels([]string{"terra.io/arch=arm64"}); err == nil {
+		t.Fatal("expected error for reserved label prefix")
+	}
+}
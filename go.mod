@@ -5,11 +5,12 @@ replace github.com/containerd/containerd => github.com/containerd/containerd v1.
 replace github.com/urfave/cli => github.com/urfave/cli v1.20.1-0.20180821064027-934abfb2f102
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/Microsoft/go-winio v0.4.11 // indirect
 	github.com/Microsoft/hcsshim v0.8.3 // indirect
 	github.com/armon/go-metrics v0.0.0-20180917152333-f0300d1749da // indirect
 	github.com/containerd/containerd v1.2.0
-	github.com/containerd/continuity v0.0.0-20181203112020-004b46473808 // indirect
+	github.com/containerd/continuity v0.0.0-20181203112020-004b46473808
 	github.com/gogo/protobuf v1.1.1
 	github.com/hashicorp/go-immutable-radix v1.0.0 // indirect
 	github.com/hashicorp/go-msgpack v0.0.0-20150518234257-fa3f63826f7c // indirect
@@ -18,16 +19,17 @@ require (
 	github.com/hashicorp/memberlist v0.1.0 // indirect
 	github.com/miekg/dns v1.1.1 // indirect
 	github.com/mitchellh/go-homedir v1.0.0
-	github.com/opencontainers/go-digest v1.0.0-rc1 // indirect
+	github.com/opencontainers/go-digest v1.0.0-rc1
 	github.com/opencontainers/image-spec v1.0.1
 	github.com/opencontainers/runc v0.1.1 // indirect
 	github.com/pkg/errors v0.8.0
 	github.com/sean-/seed v0.0.0-20170313163322-e2103e2c3529 // indirect
 	github.com/sirupsen/logrus v1.2.0
 	github.com/stellarproject/element v0.0.0-20181130035726-db23484f9fd5
-	github.com/stellarproject/nebula v0.0.0-20181208021934-24de8cc9fb7f
 	github.com/urfave/cli v1.20.0
 	go.etcd.io/bbolt v1.3.0
 	golang.org/x/crypto v0.0.0-20181203042331-505ab145d0a9
+	golang.org/x/net v0.0.0-20180925072008-f04abc6bdfa7
 	google.golang.org/grpc v1.17.0
+	gopkg.in/yaml.v3 v3.0.1
 )
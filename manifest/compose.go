@@ -0,0 +1,482 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// maxOverrideSplits is the maximum number of manifests a manifest is split
+// into to apply variable overrides
+const maxOverrideSplits = 64
+
+var (
+	composeType  = reflect.TypeOf(composeFile{})
+	assemblyType = reflect.TypeOf(api.Assembly{})
+
+	manifestPath = regexp.MustCompile(`^manifests\[(\d+)\]`)
+)
+
+// Override sets variables for the nodes matching the selector
+type Override struct {
+	Selector  string            `json:"selector"`
+	Variables map[string]string `json:"variables"`
+}
+
+// Overlay patches the assemblies of the composed manifests
+type Overlay struct {
+	// Image is the image or a glob pattern (e.g. docker.io/example/*) of the assemblies to patch
+	Image string `json:"image"`
+	// Patch is a JSON merge patch of the assembly; null removes a field
+	Patch map[string]interface{} `json:"patch"`
+}
+
+// composeFile is a manifest list file with composition directives.  every
+// manifest list is a composition without directives.
+type composeFile struct {
+	Include   []string          `json:"include,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+	Overrides []*Override       `json:"overrides,omitempty"`
+	Overlays  []*Overlay        `json:"overlays,omitempty"`
+	Manifests []*api.Manifest   `json:"manifests,omitempty"`
+	Updated   time.Time         `json:"updated"`
+	Revision  uint64            `json:"revision,omitempty"`
+}
+
+// origin is the file and path a manifest, override or overlay was defined at
+type origin struct {
+	file    string
+	decoder *decoder
+	path    string
+}
+
+type override struct {
+	*Override
+	origin
+	selector Selector
+}
+
+type overlay struct {
+	*Overlay
+	origin
+}
+
+type composer struct {
+	loaded    map[string]bool
+	errs      ValidationErrors
+	variables map[string]string
+	overrides []*override
+	overlays  []*overlay
+	manifests []*api.Manifest
+	origins   []origin
+}
+
+// Compose returns the manifest list composed from the file or directory and
+// the overlay files.  files are decoded in the format detected from their
+// extension.  a file can include other files and directories with glob
+// patterns relative to the file and set variables, variable overrides for the
+// nodes matching a selector and overlays patching assemblies.  directories
+// include every manifest list file in the directory and its subdirectories in
+// lexical order.
+//
+// included files are composed before the including file and each file is only
+// composed once.  variables of later files replace variables of earlier files
+// and overlays are applied in order after every file is composed.  the
+// variables and matching overrides are the defaults of the variables of each
+// manifest; manifests are split by selector where overrides apply to some of
+// their nodes.
+func Compose(root string, overlays ...string) (*api.ManifestList, ValidationErrors) {
+	c := &composer{
+		loaded:    map[string]bool{},
+		variables: map[string]string{},
+	}
+	c.add(root)
+	for _, o := range overlays {
+		c.add(o)
+	}
+	if c.errs.Err() != nil {
+		return nil, c.errs
+	}
+	c.applyOverlays()
+	c.applyVariables()
+	if c.errs.Err() != nil {
+		return nil, c.errs
+	}
+
+	ml := &api.ManifestList{
+		Manifests: c.manifests,
+	}
+	// manifests split by overrides report the same errors
+	reported := map[ValidationError]bool{}
+	for _, e := range Validate(ml) {
+		c.locate(e, root)
+		if !reported[*e] {
+			reported[*e] = true
+			c.errs = append(c.errs, e)
+		}
+	}
+	return ml, c.errs
+}
+
+// add composes the file or the files in the directory
+func (c *composer) add(p string) {
+	info, err := os.Stat(p)
+	if err != nil {
+		c.errs = append(c.errs, &ValidationError{File: p, Severity: SeverityError, Message: err.Error()})
+		return
+	}
+	if !info.IsDir() {
+		c.load(p)
+		return
+	}
+	files, err := composeFiles(p)
+	if err != nil {
+		c.errs = append(c.errs, &ValidationError{File: p, Severity: SeverityError, Message: err.Error()})
+		return
+	}
+	for _, f := range files {
+		c.load(f)
+	}
+}
+
+// composeFiles returns the manifest list files in the directory and its
+// subdirectories.  hidden files and directories are skipped.
+func composeFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if _, err := ParseFormat(strings.TrimPrefix(filepath.Ext(p), ".")); err == nil {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func (c *composer) load(p string) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		c.errs = append(c.errs, &ValidationError{File: p, Severity: SeverityError, Message: err.Error()})
+		return
+	}
+	if c.loaded[abs] {
+		return
+	}
+	c.loaded[abs] = true
+
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		c.errs = append(c.errs, &ValidationError{File: p, Severity: SeverityError, Message: err.Error()})
+		return
+	}
+	d := newDecoder(data)
+	var f composeFile
+	ok := d.decode(DetectFormat(p, data), composeType, &f)
+	c.addErrors(p, d.errs)
+	if !ok {
+		return
+	}
+
+	for i, pattern := range f.Include {
+		o := origin{file: p, decoder: d, path: fmt.Sprintf("include[%d]", i)}
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(p), pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			c.errorf(o, "", "invalid include %q: %s", f.Include[i], err)
+			continue
+		}
+		if len(matches) == 0 {
+			c.errorf(o, "", "include %q matches no files", f.Include[i])
+			continue
+		}
+		for _, m := range matches {
+			c.add(m)
+		}
+	}
+	for _, k := range keys(f.Variables) {
+		if !identifier.MatchString(k) {
+			c.errorf(origin{file: p, decoder: d, path: "variables." + k}, "", "invalid name %q; names must be letters, digits and underscores", k)
+		}
+		c.variables[k] = f.Variables[k]
+	}
+	for i, o := range f.Overrides {
+		ov := &override{
+			Override: o,
+			origin:   origin{file: p, decoder: d, path: fmt.Sprintf("overrides[%d]", i)},
+		}
+		s, err := ParseSelector(o.Selector)
+		if err != nil {
+			c.errorf(ov.origin, ".selector", "%s", err)
+			continue
+		}
+		ov.selector = s
+		c.overrides = append(c.overrides, ov)
+	}
+	for i, o := range f.Overlays {
+		ov := &overlay{
+			Overlay: o,
+			origin:  origin{file: p, decoder: d, path: fmt.Sprintf("overlays[%d]", i)},
+		}
+		if o.Image == "" {
+			c.errorf(ov.origin, ".image", "image is required")
+			continue
+		}
+		if _, err := path.Match(o.Image, ""); err != nil {
+			c.errorf(ov.origin, ".image", "invalid image pattern %q: %s", o.Image, err)
+			continue
+		}
+		// the patch is checked against the assembly fields like the
+		// decoded files
+		n := len(d.errs)
+		d.value(o.Patch, assemblyType, ov.path+".patch")
+		if len(d.errs) > n {
+			c.addErrors(p, d.errs[n:])
+			continue
+		}
+		c.overlays = append(c.overlays, ov)
+	}
+	for i, m := range f.Manifests {
+		c.manifests = append(c.manifests, m)
+		c.origins = append(c.origins, origin{file: p, decoder: d, path: fmt.Sprintf("manifests[%d]", i)})
+	}
+}
+
+// applyOverlays patches the assemblies matching each overlay in order
+func (c *composer) applyOverlays() {
+	for _, ov := range c.overlays {
+		matched := false
+		for _, m := range c.manifests {
+			for i, a := range m.Assemblies {
+				if ok, _ := path.Match(ov.Image, a.Image); !ok && ov.Image != a.Image {
+					continue
+				}
+				matched = true
+				patched, err := patch(a, ov)
+				if err != nil {
+					c.errorf(ov.origin, ".patch", "%s", err)
+					return
+				}
+				m.Assemblies[i] = patched
+			}
+		}
+		if !matched {
+			c.warnf(ov.origin, ".image", "overlay matches no assemblies")
+		}
+	}
+}
+
+// patch returns the assembly with the merge patch of the overlay applied
+func patch(a *api.Assembly, ov *overlay) (*api.Assembly, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	// scalars are converted to strings for string fields such as parameters
+	doc = newDecoder(nil).value(mergePatch(doc, ov.Patch), assemblyType, "")
+	if data, err = json.Marshal(doc); err != nil {
+		return nil, err
+	}
+	var patched *api.Assembly
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+// mergePatch applies the JSON merge patch (RFC 7386) to the document
+func mergePatch(doc, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	merged := map[string]interface{}{}
+	if d, ok := doc.(map[string]interface{}); ok {
+		for k, v := range d {
+			merged[k] = v
+		}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = mergePatch(merged[k], v)
+	}
+	return merged
+}
+
+// split is a part of a manifest split by override selectors
+type split struct {
+	selector  Selector
+	variables map[string]string
+	// selected is true if the selector was extended
+	selected bool
+}
+
+// applyVariables sets the default variables of each manifest and splits the
+// manifests where overrides apply to some of their nodes
+func (c *composer) applyVariables() {
+	if len(c.variables) == 0 && len(c.overrides) == 0 {
+		return
+	}
+	var (
+		manifests []*api.Manifest
+		origins   []origin
+	)
+	for i, m := range c.manifests {
+		splits := c.splits(m, c.origins[i])
+		for _, s := range splits {
+			sm := *m
+			sm.Variables = mergeVariables(s.variables, m.Variables)
+			if s.selected {
+				sm.Selector = s.selector.String()
+			}
+			manifests = append(manifests, &sm)
+			origins = append(origins, c.origins[i])
+		}
+	}
+	c.manifests, c.origins = manifests, origins
+}
+
+// splits returns the selectors and default variables of the parts of the
+// manifest.  for each override the nodes of a part are split into the nodes
+// matching the override and, for each term of the override, the nodes not
+// matching the term but the previous terms.
+func (c *composer) splits(m *api.Manifest, o origin) []*split {
+	base, err := ParseSelector(m.Selector)
+	if err != nil {
+		// reported when validating
+		base = nil
+	}
+	splits := []*split{{selector: base, variables: c.variables}}
+	for _, ov := range c.overrides {
+		if !overrides(ov.Variables, m.Variables) {
+			continue
+		}
+		if len(m.Labels) > 0 {
+			c.errorf(o, ".labels", "variable overrides cannot be applied to manifests with the deprecated labels; use a selector (see tctl manifest migrate)")
+			return splits[:1]
+		}
+		var next []*split
+		for _, s := range splits {
+			matching := append(append(Selector{}, s.selector...), ov.selector...)
+			if !matching.satisfiable() {
+				next = append(next, s)
+				continue
+			}
+			var rest []*split
+			for i, r := range ov.selector {
+				sel := append(append(append(Selector{}, s.selector...), ov.selector[:i]...), r.negate())
+				if sel.satisfiable() {
+					rest = append(rest, &split{selector: sel, variables: s.variables, selected: true})
+				}
+			}
+			variables := mergeVariables(s.variables, ov.Variables)
+			if len(rest) == 0 {
+				// every node of the part matches the override
+				next = append(next, &split{selector: s.selector, variables: variables, selected: s.selected})
+				continue
+			}
+			next = append(next, &split{selector: matching, variables: variables, selected: true})
+			next = append(next, rest...)
+		}
+		if len(next) > maxOverrideSplits {
+			c.errorf(o, "", "variable overrides split the manifest into more than %d manifests", maxOverrideSplits)
+			return splits[:1]
+		}
+		splits = next
+	}
+	return splits
+}
+
+// overrides returns true if the override sets a variable the manifest does not
+func overrides(override, manifest map[string]string) bool {
+	for k := range override {
+		if _, ok := manifest[k]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeVariables returns the variables with the values of the overrides
+func mergeVariables(variables, overrides map[string]string) map[string]string {
+	if len(variables) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	merged := map[string]string{}
+	for k, v := range variables {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+func (c *composer) errorf(o origin, suffix, format string, args ...interface{}) {
+	c.errs = append(c.errs, o.error(SeverityError, suffix, fmt.Sprintf(format, args...)))
+}
+
+func (c *composer) warnf(o origin, suffix, format string, args ...interface{}) {
+	c.errs = append(c.errs, o.error(SeverityWarning, suffix, fmt.Sprintf(format, args...)))
+}
+
+func (o origin) error(severity, suffix, msg string) *ValidationError {
+	e := &ValidationError{
+		File:     o.file,
+		Path:     o.path + suffix,
+		Severity: severity,
+		Message:  msg,
+	}
+	o.decoder.locate(e)
+	return e
+}
+
+func (c *composer) addErrors(file string, errs ValidationErrors) {
+	for _, e := range errs {
+		e.File = file
+		c.errs = append(c.errs, e)
+	}
+}
+
+// locate sets the file, path, line and column of an error of the composed
+// manifest list to the manifest it refers to in its file
+func (c *composer) locate(e *ValidationError, root string) {
+	m := manifestPath.FindStringSubmatch(e.Path)
+	if m == nil {
+		e.File = root
+		return
+	}
+	i, _ := strconv.Atoi(m[1])
+	o := c.origins[i]
+	e.File, e.Path = o.file, o.path+strings.TrimPrefix(e.Path, m[0])
+	o.decoder.locate(e)
+}
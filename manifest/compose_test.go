@@ -0,0 +1,142 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "terra-compose-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCompose(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"root.yaml": `include:
+  - teams
+variables:
+  log_level: info
+  dns: 10.0.0.2
+overrides:
+  - selector: env=prod
+    variables:
+      log_level: warn
+  - selector: zone in (a,b),gpu
+    variables:
+      dns: 10.0.0.3
+`,
+		"teams/db.toml": `[[manifests]]
+node_ids = ["db-*"]
+selector = "env=prod"
+
+[[manifests.assemblies]]
+image = "docker.io/example/db:1.0"
+`,
+		"teams/web/web.json": `{"manifests": [{"selector": "role=web", "variables": {"dns": "1.1.1.1"},
+  "assemblies": [{"image": "docker.io/example/web:1.0", "parameters": {"workers": "4", "debug": "true"}}]}]}`,
+		"teams/.hidden.json": `{"bogus": true}`,
+		"prod.yaml": `overlays:
+  - image: docker.io/example/web:*
+    patch:
+      image: docker.io/example/web:2.0
+      parameters:
+        workers: 8
+        debug: null
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	ml, errs := Compose(filepath.Join(dir, "root.yaml"), filepath.Join(dir, "prod.yaml"))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	nodes := []struct {
+		id        string
+		labels    map[string]string
+		image     string
+		variables map[string]string
+	}{
+		{"db-1", map[string]string{"env": "prod"}, "docker.io/example/db:1.0", map[string]string{"log_level": "warn", "dns": "10.0.0.2"}},
+		{"db-2", map[string]string{"env": "prod", "zone": "a", "gpu": ""}, "docker.io/example/db:1.0", map[string]string{"log_level": "warn", "dns": "10.0.0.3"}},
+		{"db-3", map[string]string{"env": "dev"}, "", nil},
+		{"web-1", map[string]string{"role": "web", "env": "prod", "zone": "a", "gpu": ""}, "docker.io/example/web:2.0", map[string]string{"log_level": "warn", "dns": "1.1.1.1"}},
+		{"web-2", map[string]string{"role": "web"}, "docker.io/example/web:2.0", map[string]string{"log_level": "info", "dns": "1.1.1.1"}},
+	}
+	for _, n := range nodes {
+		var matched []int
+		for i, m := range ml.Manifests {
+			if Matches(m, n.id, n.labels) {
+				matched = append(matched, i)
+			}
+		}
+		if n.image == "" {
+			if len(matched) != 0 {
+				t.Errorf("%s: expected no manifests; received %v", n.id, matched)
+			}
+			continue
+		}
+		if len(matched) != 1 {
+			t.Errorf("%s: expected one manifest; received %v", n.id, matched)
+			continue
+		}
+		m := ml.Manifests[matched[0]]
+		if image := m.Assemblies[0].Image; image != n.image {
+			t.Errorf("%s: expected image %s; received %s", n.id, n.image, image)
+		}
+		if formatMap(m.Variables) != formatMap(n.variables) {
+			t.Errorf("%s: expected variables %v; received %v", n.id, n.variables, m.Variables)
+		}
+	}
+
+	for _, m := range ml.Manifests {
+		if a := m.Assemblies[0]; a.Image == "docker.io/example/web:2.0" && formatMap(a.Parameters) != "workers=8" {
+			t.Errorf("expected patched parameters; received %v", a.Parameters)
+		}
+	}
+}
+
+func TestComposeErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"root.yaml": `include:
+  - teams/*.yaml
+  - missing/*.yaml
+`,
+		"teams/web.yaml": `manifests:
+  - selector: role=web
+    assemblies:
+      - image: docker.io/example/web
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	_, errs := Compose(filepath.Join(dir, "root.yaml"))
+	if len(errs) != 1 {
+		t.Fatalf("expected include error; received %v", errs)
+	}
+	if e := errs[0]; e.File != filepath.Join(dir, "root.yaml") || e.Line != 3 || e.Path != "include[1]" {
+		t.Errorf("unexpected include error %s", e)
+	}
+
+	_, errs = Compose(filepath.Join(dir, "teams"))
+	if len(errs) != 1 {
+		t.Fatalf("expected image error; received %v", errs)
+	}
+	if e := errs[0]; e.File != filepath.Join(dir, "teams", "web.yaml") || e.Line != 4 || e.Path != "manifests[0].assemblies[0].image" {
+		t.Errorf("unexpected image error %s", e)
+	}
+}
@@ -0,0 +1,451 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	listType = reflect.TypeOf(api.ManifestList{})
+
+	yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+):`)
+)
+
+// Decode decodes and validates the manifest list in the format.  YAML
+// documents are merged into one list.  unknown fields are errors and every
+// error has the line and column of the field it refers to.
+func Decode(data []byte, format Format) (*api.ManifestList, ValidationErrors) {
+	d := newDecoder(data)
+	var ml *api.ManifestList
+	if !d.decode(format, listType, &ml) {
+		return nil, d.errs
+	}
+	for _, e := range Validate(ml) {
+		d.locate(e)
+		d.errs = append(d.errs, e)
+	}
+	return ml, d.errs
+}
+
+// DecodeJSON decodes and validates the JSON manifest list
+func DecodeJSON(data []byte) (*api.ManifestList, ValidationErrors) {
+	return Decode(data, FormatJSON)
+}
+
+// decoder records the position of every field of the manifest list and
+// reports fields that are not part of it
+type decoder struct {
+	data      []byte
+	positions map[string]int64
+	errs      ValidationErrors
+	// manifests is the number of manifests in the merged YAML documents
+	manifests int
+}
+
+func newDecoder(data []byte) *decoder {
+	return &decoder{
+		data:      data,
+		positions: map[string]int64{},
+	}
+}
+
+// decode decodes the data in the format into v which is a pointer to t.
+// false is returned if the data cannot be decoded.
+func (d *decoder) decode(format Format, t reflect.Type, v interface{}) bool {
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(d.data))
+		if err := d.json(dec, t, ""); err != nil {
+			d.errs = append(d.errs, d.jsonError(err))
+			return false
+		}
+		if _, err := dec.Token(); err != io.EOF {
+			d.errs = append(d.errs, d.errorAt("", d.start(dec), "unexpected data after manifest list"))
+			return false
+		}
+		return d.unmarshal(d.data, v)
+	case FormatYAML:
+		doc := map[string]interface{}{}
+		dec := yaml.NewDecoder(bytes.NewReader(d.data))
+		for {
+			var n yaml.Node
+			if err := dec.Decode(&n); err != nil {
+				if err == io.EOF {
+					break
+				}
+				d.errs = append(d.errs, d.yamlError(err))
+				return false
+			}
+			v := d.yaml(&n, t, "")
+			if v == nil {
+				continue
+			}
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				d.errs = append(d.errs, d.errorAt("", d.offset(n.Line, n.Column), "manifest list document must be a mapping"))
+				return false
+			}
+			d.merge(doc, m)
+		}
+		return d.generic(doc, v)
+	case FormatTOML:
+		var doc map[string]interface{}
+		if _, err := toml.Decode(string(d.data), &doc); err != nil {
+			d.errs = append(d.errs, d.tomlError(err))
+			return false
+		}
+		d.positions = tomlPositions(d.data)
+		return d.generic(d.value(doc, t, ""), v)
+	}
+	d.errs = append(d.errs, &ValidationError{
+		Severity: SeverityError,
+		Message:  fmt.Sprintf("unsupported format %q", format),
+	})
+	return false
+}
+
+// unmarshal decodes the JSON data into v
+func (d *decoder) unmarshal(data []byte, v interface{}) bool {
+	if err := json.Unmarshal(data, v); err != nil {
+		e := d.jsonError(err)
+		if _, ok := err.(*json.UnmarshalTypeError); ok && !bytes.Equal(data, d.data) {
+			// the offset is in the converted document
+			e.Line, e.Column = 0, 0
+		}
+		d.errs = append(d.errs, e)
+		return false
+	}
+	return true
+}
+
+// generic decodes the decoded YAML or TOML values into v
+func (d *decoder) generic(doc, v interface{}) bool {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		d.errs = append(d.errs, &ValidationError{Severity: SeverityError, Message: err.Error()})
+		return false
+	}
+	return d.unmarshal(data, v)
+}
+
+// json walks the next value which is decoded into t.  t is nil for values of
+// unknown fields.
+func (d *decoder) json(dec *json.Decoder, t reflect.Type, path string) error {
+	t = indirect(t)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			offset := d.start(dec)
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			ft, p := d.field(t, path, tok.(string), offset)
+			if err := d.json(dec, ft, p); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		et := elem(t)
+		for i := 0; dec.More(); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			d.positions[p] = d.start(dec)
+			if err := d.json(dec, et, p); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	return err
+}
+
+// yaml returns the value of the node decoded for t.  scalars decoded into
+// strings keep their text so unquoted numbers and booleans can be used for
+// parameters.
+func (d *decoder) yaml(n *yaml.Node, t reflect.Type, path string) interface{} {
+	t = indirect(t)
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return d.yaml(n.Content[0], t, path)
+	case yaml.AliasNode:
+		return d.yaml(n.Alias, t, path)
+	case yaml.MappingNode:
+		m := map[string]interface{}{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			ft, p := d.field(t, path, key.Value, d.offset(key.Line, key.Column))
+			m[key.Value] = d.yaml(value, ft, p)
+		}
+		return m
+	case yaml.SequenceNode:
+		et := elem(t)
+		s := []interface{}{}
+		for i, item := range n.Content {
+			p := d.index(path, i)
+			d.positions[p] = d.offset(item.Line, item.Column)
+			s = append(s, d.yaml(item, et, p))
+		}
+		return s
+	}
+	if n.Tag == "!!null" {
+		return nil
+	}
+	if t != nil && t.Kind() == reflect.String {
+		return n.Value
+	}
+	var v interface{}
+	if err := n.Decode(&v); err != nil {
+		return n.Value
+	}
+	return v
+}
+
+// value returns the decoded TOML value for t
+func (d *decoder) value(v interface{}, t reflect.Type, path string) interface{} {
+	t = indirect(t)
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for _, k := range sortedKeys(v) {
+			ft, p := d.field(t, path, k, -1)
+			m[k] = d.value(v[k], ft, p)
+		}
+		return m
+	case []map[string]interface{}:
+		s := []interface{}{}
+		for i, item := range v {
+			s = append(s, d.value(item, elem(t), fmt.Sprintf("%s[%d]", path, i)))
+		}
+		return s
+	case []interface{}:
+		s := []interface{}{}
+		for i, item := range v {
+			s = append(s, d.value(item, elem(t), fmt.Sprintf("%s[%d]", path, i)))
+		}
+		return s
+	case int64, float64, bool:
+		if t != nil && t.Kind() == reflect.String {
+			return fmt.Sprint(v)
+		}
+	}
+	return v
+}
+
+// field returns the type and path of the field for the key and reports
+// unknown fields.  keys match json tags regardless of case as they do when
+// unmarshaling.  offset is the position of the key or -1 if the position was
+// already recorded.
+func (d *decoder) field(t reflect.Type, path, key string, offset int64) (reflect.Type, string) {
+	ft, name, ok := field(t, key)
+	p := name
+	if path != "" {
+		p = path + "." + name
+	}
+	if offset >= 0 {
+		d.positions[p] = offset
+	}
+	if !ok {
+		e := d.errorAt(p, offset, fmt.Sprintf("unknown field %q", key))
+		if offset < 0 {
+			d.locate(e)
+		}
+		d.errs = append(d.errs, e)
+	}
+	return ft, p
+}
+
+func field(t reflect.Type, key string) (reflect.Type, string, bool) {
+	if t == nil || t == timeType {
+		return nil, key, true
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		return t.Elem(), key, true
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			if strings.EqualFold(name, key) {
+				return t.Field(i).Type, name, true
+			}
+		}
+		return nil, key, false
+	}
+	// the value has the wrong type which is reported when unmarshaling
+	return nil, key, true
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func elem(t reflect.Type) reflect.Type {
+	if t != nil && t.Kind() == reflect.Slice {
+		return t.Elem()
+	}
+	return nil
+}
+
+// index returns the path of the element of the array.  manifests are numbered
+// across the merged documents.
+func (d *decoder) index(path string, i int) string {
+	if path == "manifests" {
+		i += d.manifests
+	}
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// merge merges the document into the manifest list
+func (d *decoder) merge(list, doc map[string]interface{}) {
+	for k, v := range doc {
+		manifests, ok := v.([]interface{})
+		if !ok || k != "manifests" {
+			list[k] = v
+			continue
+		}
+		existing, _ := list[k].([]interface{})
+		list[k] = append(existing, manifests...)
+		d.manifests += len(manifests)
+	}
+}
+
+// start returns the offset of the next token
+func (d *decoder) start(dec *json.Decoder) int64 {
+	offset := dec.InputOffset()
+	for offset < int64(len(d.data)) && strings.IndexByte(" \t\r\n,:", d.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// offset returns the offset of the one based line and column
+func (d *decoder) offset(line, column int) int64 {
+	var offset int64
+	for l := 1; l < line; l++ {
+		i := bytes.IndexByte(d.data[offset:], '\n')
+		if i < 0 {
+			break
+		}
+		offset += int64(i) + 1
+	}
+	return offset + int64(column) - 1
+}
+
+func (d *decoder) jsonError(err error) *ValidationError {
+	var offset int64
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *json.UnmarshalTypeError:
+		offset = e.Offset
+	default:
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = io.ErrUnexpectedEOF
+			offset = int64(len(d.data))
+		}
+	}
+	return d.errorAt("", offset, err.Error())
+}
+
+func (d *decoder) yamlError(err error) *ValidationError {
+	e := &ValidationError{
+		Severity: SeverityError,
+		Message:  err.Error(),
+	}
+	if m := yamlErrorLine.FindStringSubmatch(e.Message); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		e.Message = strings.TrimSpace(strings.TrimPrefix(e.Message, m[0]))
+	}
+	return e
+}
+
+func (d *decoder) tomlError(err error) *ValidationError {
+	if pe, ok := err.(toml.ParseError); ok {
+		msg := pe.Message
+		if msg == "" {
+			msg = pe.Error()
+		}
+		return d.errorAt("", int64(pe.Position.Start), msg)
+	}
+	return &ValidationError{
+		Severity: SeverityError,
+		Message:  errors.Cause(err).Error(),
+	}
+}
+
+func (d *decoder) errorAt(path string, offset int64, msg string) *ValidationError {
+	e := &ValidationError{
+		Path:     path,
+		Severity: SeverityError,
+		Message:  msg,
+	}
+	if offset >= 0 {
+		e.Line, e.Column = lineColumn(d.data, offset)
+	}
+	return e
+}
+
+// locate sets the line and column of the error to the field of its path or
+// the closest enclosing field
+func (d *decoder) locate(e *ValidationError) {
+	for p := e.Path; p != ""; p = parentPath(p) {
+		if offset, ok := d.positions[p]; ok {
+			e.Line, e.Column = lineColumn(d.data, offset)
+			return
+		}
+	}
+}
+
+func parentPath(p string) string {
+	i := strings.LastIndexAny(p, ".[")
+	if i < 0 {
+		return ""
+	}
+	return p[:i]
+}
+
+// lineColumn returns the one based line and column of the offset
+func lineColumn(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	return line, int(offset) - bytes.LastIndexByte(before, '\n')
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
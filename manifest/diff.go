@@ -0,0 +1,247 @@
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// ChangeType is the type of change between two manifest lists
+type ChangeType string
+
+const (
+	// Added is an assembly only present in the new manifest list
+	Added ChangeType = "+"
+	// Removed is an assembly only present in the old manifest list
+	Removed ChangeType = "-"
+	// Changed is an assembly present in both with different configuration
+	Changed ChangeType = "~"
+)
+
+// Change is a difference for an assembly in a manifest target
+type Change struct {
+	Type ChangeType
+	// Target describes the nodes targeted by the manifest
+	Target string
+	Image  string
+	// Details describe the changed fields
+	Details []string
+}
+
+func (c *Change) String() string {
+	s := fmt.Sprintf("%s %s %s", c.Type, c.Target, c.Image)
+	if len(c.Details) > 0 {
+		s += " (" + strings.Join(c.Details, "; ") + ")"
+	}
+	return s
+}
+
+// Target returns a description of the nodes targeted by the manifest.  every
+// targeting field is included so manifests selecting different nodes have
+// different targets.
+func Target(m *api.Manifest) string {
+	parts := []string{}
+	if m.NodeID != "" {
+		parts = append(parts, "node="+m.NodeID)
+	}
+	if len(m.NodeIDs) > 0 {
+		parts = append(parts, "nodes="+strings.Join(m.NodeIDs, ","))
+	}
+	if len(m.Labels) > 0 {
+		parts = append(parts, "labels="+formatMap(m.Labels))
+	}
+	if m.Selector != "" {
+		parts = append(parts, "selector="+strconv.Quote(m.Selector))
+	}
+	if len(m.Exclude) > 0 {
+		parts = append(parts, "exclude="+strings.Join(m.Exclude, ","))
+	}
+	if len(parts) == 0 {
+		return "*"
+	}
+	return strings.Join(parts, " ")
+}
+
+// Diff returns the assembly changes from the old manifest list to the new
+// manifest list grouped by manifest target
+func Diff(old, new *api.ManifestList) []*Change {
+	oldAssemblies := targetAssemblies(old)
+	newAssemblies := targetAssemblies(new)
+
+	keys := map[string]bool{}
+	for k := range oldAssemblies {
+		keys[k] = true
+	}
+	for k := range newAssemblies {
+		keys[k] = true
+	}
+	sorted := []string{}
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	changes := []*Change{}
+	for _, k := range sorted {
+		o, n := oldAssemblies[k], newAssemblies[k]
+		switch {
+		case o == nil:
+			changes = append(changes, &Change{Type: Added, Target: n.target, Image: n.assembly.Image})
+		case n == nil:
+			changes = append(changes, &Change{Type: Removed, Target: o.target, Image: o.assembly.Image})
+		default:
+			if details := assemblyDiff(o, n); len(details) > 0 {
+				changes = append(changes, &Change{Type: Changed, Target: n.target, Image: n.assembly.Image, Details: details})
+			}
+		}
+	}
+	return changes
+}
+
+type targetAssembly struct {
+	target   string
+	manifest *api.Manifest
+	assembly *api.Assembly
+}
+
+// retry returns the assembly retry policy or the manifest policy
+func (t *targetAssembly) retry() *api.RetryPolicy {
+	if t.assembly.Retry != nil {
+		return t.assembly.Retry
+	}
+	return t.manifest.Retry
+}
+
+// policy returns the assembly execution policy or the manifest policy
+func (t *targetAssembly) policy() *api.ExecutionPolicy {
+	if t.assembly.Policy != nil {
+		return t.assembly.Policy
+	}
+	return t.manifest.Policy
+}
+
+// variables returns the manifest variables overridden by the assembly variables
+func (t *targetAssembly) variables() map[string]string {
+	vars := map[string]string{}
+	for k, v := range t.manifest.Variables {
+		vars[k] = v
+	}
+	for k, v := range t.assembly.Variables {
+		vars[k] = v
+	}
+	return vars
+}
+
+func targetAssemblies(ml *api.ManifestList) map[string]*targetAssembly {
+	assemblies := map[string]*targetAssembly{}
+	if ml == nil {
+		return assemblies
+	}
+	for _, m := range ml.Manifests {
+		target := Target(m)
+		for _, a := range m.Assemblies {
+			assemblies[target+"\x00"+a.Image] = &targetAssembly{
+				target:   target,
+				manifest: m,
+				assembly: a,
+			}
+		}
+	}
+	return assemblies
+}
+
+// assemblyDiff returns the differences of the assembly configuration including
+// the retry policy, execution policy and variables inherited from the manifest
+func assemblyDiff(old, new *targetAssembly) []string {
+	details := []string{}
+	if !reflect.DeepEqual(normalize(old.assembly.Requires), normalize(new.assembly.Requires)) {
+		details = append(details, fmt.Sprintf("requires: [%s] -> [%s]", strings.Join(old.assembly.Requires, ","), strings.Join(new.assembly.Requires, ",")))
+	}
+	details = append(details, mapDiff("parameter", old.assembly.Parameters, new.assembly.Parameters)...)
+	details = append(details, mapDiff("variable", old.variables(), new.variables())...)
+	if o, n := old.assembly.Timeout, new.assembly.Timeout; o != n {
+		details = append(details, fmt.Sprintf("timeout: %s -> %s", formatValue(o), formatValue(n)))
+	}
+	if o, n := old.retry(), new.retry(); !proto.Equal(o, n) {
+		details = append(details, fmt.Sprintf("retry: %s -> %s", formatMessage(o), formatMessage(n)))
+	}
+	if o, n := old.policy(), new.policy(); !proto.Equal(o, n) {
+		details = append(details, fmt.Sprintf("policy: %s -> %s", formatMessage(o), formatMessage(n)))
+	}
+	if o, n := old.assembly.Resources, new.assembly.Resources; !proto.Equal(o, n) {
+		details = append(details, fmt.Sprintf("resources: %s -> %s", formatMessage(o), formatMessage(n)))
+	}
+	return details
+}
+
+// mapDiff returns the added, removed and changed keys of the maps
+func mapDiff(name string, old, new map[string]string) []string {
+	details := []string{}
+	keys := map[string]bool{}
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range new {
+		keys[k] = true
+	}
+	sorted := []string{}
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	for _, k := range sorted {
+		o, ook := old[k]
+		n, nok := new[k]
+		switch {
+		case !ook:
+			details = append(details, fmt.Sprintf("+%s %s=%s", name, k, n))
+		case !nok:
+			details = append(details, fmt.Sprintf("-%s %s", name, k))
+		case o != n:
+			details = append(details, fmt.Sprintf("%s %s: %s -> %s", name, k, o, n))
+		}
+	}
+	return details
+}
+
+func formatValue(v string) string {
+	if v == "" {
+		return "default"
+	}
+	return v
+}
+
+// formatMessage returns the compact text of the message or default if unset
+func formatMessage(m proto.Message) string {
+	if reflect.ValueOf(m).IsNil() {
+		return "default"
+	}
+	if s := strings.TrimSpace(proto.CompactTextString(m)); s != "" {
+		return "{" + s + "}"
+	}
+	return "{}"
+}
+
+func normalize(v []string) []string {
+	s := append([]string{}, v...)
+	sort.Strings(s)
+	return s
+}
+
+func formatMap(m map[string]string) string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for _, k := range keys {
+		parts = append(parts, k+"="+m[k])
+	}
+	return strings.Join(parts, ",")
+}
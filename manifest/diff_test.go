@@ -0,0 +1,96 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func TestDiff(t *testing.T) {
+	old := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				Assemblies: []*api.Assembly{
+					{Image: "a", Parameters: map[string]string{"foo": "bar"}},
+					{Image: "b"},
+				},
+			},
+		},
+	}
+	new := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				Assemblies: []*api.Assembly{
+					{Image: "a", Parameters: map[string]string{"foo": "baz"}},
+				},
+			},
+			{
+				NodeID: "web",
+				Assemblies: []*api.Assembly{
+					{Image: "c"},
+				},
+			},
+		},
+	}
+
+	expected := []string{
+		"~ * a (parameter foo: bar -> baz)",
+		"- * b",
+		"+ node=web c",
+	}
+	changes := []string{}
+	for _, c := range Diff(old, new) {
+		changes = append(changes, c.String())
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %q; received %q", expected, changes)
+	}
+
+	if changes := Diff(old, old); len(changes) != 0 {
+		t.Fatalf("expected no changes; received %v", changes)
+	}
+}
+
+func TestDiffSettings(t *testing.T) {
+	old := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				Selector:  "role=web",
+				Variables: map[string]string{"env": "dev"},
+				Assemblies: []*api.Assembly{
+					{Image: "a"},
+				},
+			},
+			{
+				Selector: "role=db",
+				Assemblies: []*api.Assembly{
+					{Image: "a"},
+				},
+			},
+		},
+	}
+	new := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				Selector:  "role=web",
+				Variables: map[string]string{"env": "prod"},
+				Assemblies: []*api.Assembly{
+					{Image: "a", Timeout: "5m", Retry: &api.RetryPolicy{MaxAttempts: 3}},
+				},
+			},
+		},
+	}
+
+	expected := []string{
+		`- selector="role=db" a`,
+		`~ selector="role=web" a (variable env: dev -> prod; timeout: default -> 5m; retry: default -> {max_attempts:3})`,
+	}
+	changes := []string{}
+	for _, c := range Diff(old, new) {
+		changes = append(changes, c.String())
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %q; received %q", expected, changes)
+	}
+}
@@ -0,0 +1,231 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Format is a manifest list file format
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+var (
+	tomlTable = regexp.MustCompile(`^\[\[?\s*([^\[\]]+?)\s*\]\]?\s*(#.*)?$`)
+	tomlKey   = regexp.MustCompile(`^((?:[A-Za-z0-9_-]+|"[^"]*"|'[^']*')(?:\s*\.\s*(?:[A-Za-z0-9_-]+|"[^"]*"|'[^']*'))*)\s*=`)
+)
+
+// ParseFormat returns the format for the name
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case FormatJSON, FormatYAML, FormatTOML:
+		return f, nil
+	case "yml":
+		return FormatYAML, nil
+	}
+	return "", errors.Errorf("unsupported format %q", name)
+}
+
+// DetectFormat returns the format of the manifest list file from its
+// extension or, for other files, from its content
+func DetectFormat(path string, data []byte) Format {
+	if f, err := ParseFormat(strings.TrimPrefix(filepath.Ext(path), ".")); err == nil {
+		return f
+	}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "{"):
+			return FormatJSON
+		case tomlTable.MatchString(line), tomlKey.MatchString(line):
+			return FormatTOML
+		}
+		return FormatYAML
+	}
+	return FormatJSON
+}
+
+// Encode returns the manifest list in the format.  the fields are named as in
+// the JSON format so the output can be decoded again.
+func Encode(ml *api.ManifestList, format Format) ([]byte, error) {
+	data, err := json.MarshalIndent(ml, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatJSON:
+		return append(data, '\n'), nil
+	case FormatYAML:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		n, err := yamlNode(dec)
+		if err != nil {
+			return nil, err
+		}
+		buf := &bytes.Buffer{}
+		enc := yaml.NewEncoder(buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(n); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatTOML:
+		var v map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		buf := &bytes.Buffer{}
+		if err := toml.NewEncoder(buf).Encode(v); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, errors.Errorf("unsupported format %q", format)
+}
+
+// yamlNode returns the next JSON value as a YAML node keeping the field order
+func yamlNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if tok == '[' {
+			n = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		for dec.More() {
+			if n.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			v, err := yamlNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, v)
+		}
+		// closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tok}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(tok.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: tok.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(tok)}, nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+}
+
+// tomlPositions returns the offsets of the tables and keys of the TOML
+// document by path.  the parser does not report positions so the document is
+// scanned for table headers and keys; keys of inline tables and multi-line
+// values are not found and errors for them refer to the enclosing field.
+func tomlPositions(data []byte) map[string]int64 {
+	var (
+		positions = map[string]int64{}
+		// arrays are the number of tables in each array of tables
+		arrays    = map[string]int{}
+		table     string
+		offset    int64
+		multiline string
+	)
+	resolve := func(parts []string) string {
+		p := ""
+		for _, part := range parts {
+			if p != "" {
+				p += "."
+			}
+			p += part
+			if n, ok := arrays[p]; ok {
+				p = fmt.Sprintf("%s[%d]", p, n-1)
+			}
+		}
+		return p
+	}
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		start := offset
+		offset += int64(len(line))
+		trimmed := strings.TrimSpace(line)
+		column := int64(strings.Index(line, trimmed))
+		if multiline != "" {
+			if strings.Contains(trimmed, multiline) {
+				multiline = ""
+			}
+			continue
+		}
+		if m := tomlTable.FindStringSubmatch(trimmed); m != nil {
+			parts := tomlKeyParts(m[1])
+			if strings.HasPrefix(trimmed, "[[") {
+				array := parts[len(parts)-1]
+				if parent := resolve(parts[:len(parts)-1]); parent != "" {
+					array = parent + "." + array
+				}
+				if _, ok := positions[array]; !ok {
+					positions[array] = start + column
+				}
+				arrays[array]++
+				table = fmt.Sprintf("%s[%d]", array, arrays[array]-1)
+			} else {
+				table = resolve(parts)
+			}
+			positions[table] = start + column
+			continue
+		}
+		if m := tomlKey.FindStringSubmatch(trimmed); m != nil {
+			p := strings.Join(tomlKeyParts(m[1]), ".")
+			if table != "" {
+				p = table + "." + p
+			}
+			positions[p] = start + column
+			for _, quote := range []string{`"""`, `'''`} {
+				if strings.Count(trimmed, quote) == 1 {
+					multiline = quote
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// tomlKeyParts splits the dotted key and removes quotes
+func tomlKeyParts(key string) []string {
+	var parts []string
+	for _, part := range strings.Split(key, ".") {
+		parts = append(parts, strings.Trim(strings.TrimSpace(part), `"'`))
+	}
+	return parts
+}
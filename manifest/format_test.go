@@ -0,0 +1,125 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	data := []byte(`manifests:
+  - node_id: dev
+    assemblies:
+      - image: docker.io/example/base:latest
+        parameters:
+          port: 80
+          debug: true
+---
+manifests:
+  - selector: env=prod
+    assemblies:
+      - image: docker.io/example/app
+        paramters: {}
+`)
+	ml, errs := Decode(data, FormatYAML)
+	if len(errs) != 2 {
+		t.Fatalf("expected unknown field and image errors; received %v", errs)
+	}
+	if e := errs[0]; e.Line != 13 || e.Column != 9 || e.Path != "manifests[1].assemblies[0].paramters" {
+		t.Errorf("unexpected unknown field error %s", e)
+	}
+	if e := errs[1]; e.Line != 12 || e.Column != 9 || e.Path != "manifests[1].assemblies[0].image" {
+		t.Errorf("unexpected image error %s", e)
+	}
+	if len(ml.Manifests) != 2 {
+		t.Fatalf("expected documents to be merged; received %d manifests", len(ml.Manifests))
+	}
+	expected := map[string]string{"port": "80", "debug": "true"}
+	if params := ml.Manifests[0].Assemblies[0].Parameters; !reflect.DeepEqual(params, expected) {
+		t.Errorf("expected parameters %v; received %v", expected, params)
+	}
+
+	if _, errs := Decode([]byte("manifests:\n  - node_id: [\n"), FormatYAML); errs.Err() == nil || errs[0].Line == 0 {
+		t.Errorf("expected syntax error with line; received %v", errs)
+	}
+}
+
+func TestDecodeTOML(t *testing.T) {
+	data := []byte(`[[manifests]]
+node_id = "dev"
+
+[[manifests.assemblies]]
+image = "docker.io/example/base:latest"
+  [manifests.assemblies.parameters]
+  port = 80
+  log-level = "debug"
+
+[[manifests]]
+selector = "env=prod"
+
+[[manifests.assemblies]]
+image = "docker.io/example/app"
+bogus = true
+`)
+	_, errs := Decode(data, FormatTOML)
+	expected := []struct {
+		path         string
+		line, column int
+	}{
+		{"manifests[1].assemblies[0].bogus", 15, 1},
+		{"manifests[0].assemblies[0].parameters.log-level", 8, 3},
+		{"manifests[1].assemblies[0].image", 14, 1},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors; received %v", len(expected), errs)
+	}
+	for i, x := range expected {
+		if e := errs[i]; e.Path != x.path || e.Line != x.line || e.Column != x.column {
+			t.Errorf("expected %s at %d:%d; received %s", x.path, x.line, x.column, e)
+		}
+	}
+
+	if _, errs := Decode([]byte("manifests = [\n"), FormatTOML); errs.Err() == nil || errs[0].Line != 1 {
+		t.Errorf("expected syntax error on line 1; received %v", errs)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	ml := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				NodeIDs:   []string{"web-*"},
+				Variables: map[string]string{"env": "prod"},
+				Assemblies: []*api.Assembly{
+					{
+						Image:      "docker.io/example/base:latest",
+						Parameters: map[string]string{"port": "80", "debug": "true"},
+						Retry:      &api.RetryPolicy{MaxAttempts: 3, Jitter: 0.5},
+					},
+				},
+			},
+			{
+				Selector:   "env=prod",
+				Assemblies: []*api.Assembly{{Image: "docker.io/example/app:1.0"}},
+			},
+		},
+		Revision: 4,
+	}
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatTOML} {
+		data, err := Encode(ml, format)
+		if err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+		decoded, errs := Decode(data, format)
+		if len(errs) > 0 {
+			t.Fatalf("%s: %s", format, errs)
+		}
+		if !reflect.DeepEqual(decoded, ml) {
+			t.Errorf("%s: expected %v; received %v", format, ml, decoded)
+		}
+		if detected := DetectFormat("manifests", data); detected != format {
+			t.Errorf("%s: detected %s", format, detected)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// ErrCycle is returned when the assembly requirements contain a cycle
+type ErrCycle struct {
+	// Path is the list of images forming the cycle with the first image repeated at the end
+	Path []string
+}
+
+func (e *ErrCycle) Error() string {
+	return fmt.Sprintf("assembly dependency cycle detected: %s", strings.Join(e.Path, " -> "))
+}
+
+// Graph is the dependency graph of assemblies keyed by image
+type Graph struct {
+	assemblies map[string]*api.Assembly
+	images     []string
+}
+
+// NewGraph returns a dependency graph for the assemblies.  required images
+// that are not in the list are added as assemblies without parameters.
+func NewGraph(assemblies []*api.Assembly) *Graph {
+	g := &Graph{
+		assemblies: map[string]*api.Assembly{},
+	}
+	for _, a := range assemblies {
+		g.add(a)
+	}
+	for _, a := range assemblies {
+		for _, req := range a.Requires {
+			if _, ok := g.assemblies[req]; !ok {
+				g.add(&api.Assembly{Image: req})
+			}
+		}
+	}
+	return g
+}
+
+func (g *Graph) add(a *api.Assembly) {
+	if _, ok := g.assemblies[a.Image]; ok {
+		return
+	}
+	g.assemblies[a.Image] = a
+	g.images = append(g.images, a.Image)
+}
+
+// Assembly returns the assembly for the image
+func (g *Graph) Assembly(image string) *api.Assembly {
+	return g.assemblies[image]
+}
+
+// Requires returns the images directly required by the image
+func (g *Graph) Requires(image string) []string {
+	a, ok := g.assemblies[image]
+	if !ok {
+		return nil
+	}
+	return a.Requires
+}
+
+// Sort returns the assemblies in dependency order where every assembly
+// is returned after the assemblies it requires.  independent assemblies
+// keep the order they were added to the graph.  an *ErrCycle is returned
+// if the requirements contain a cycle.
+func (g *Graph) Sort() ([]*api.Assembly, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		state = map[string]int{}
+		path  []string
+		order []*api.Assembly
+	)
+
+	var visit func(image string) error
+	visit = func(image string) error {
+		switch state[image] {
+		case visited:
+			return nil
+		case visiting:
+			// find the start of the cycle in the current path
+			for i, p := range path {
+				if p == image {
+					cycle := append([]string{}, path[i:]...)
+					return &ErrCycle{Path: append(cycle, image)}
+				}
+			}
+			return &ErrCycle{Path: []string{image, image}}
+		}
+		state[image] = visiting
+		path = append(path, image)
+		for _, req := range g.Requires(image) {
+			if err := visit(req); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[image] = visited
+		order = append(order, g.assemblies[image])
+		return nil
+	}
+
+	for _, image := range g.images {
+		if err := visit(image); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
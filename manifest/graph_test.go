@@ -0,0 +1,114 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func images(assemblies []*api.Assembly) []string {
+	v := []string{}
+	for _, a := range assemblies {
+		v = append(v, a.Image)
+	}
+	return v
+}
+
+func TestGraphSort(t *testing.T) {
+	cases := []struct {
+		name       string
+		assemblies []*api.Assembly
+		expected   []string
+		cycle      bool
+	}{
+		{
+			name: "independent",
+			assemblies: []*api.Assembly{
+				{Image: "a"},
+				{Image: "b"},
+			},
+			expected: []string{"a", "b"},
+		},
+		{
+			name: "transitive",
+			assemblies: []*api.Assembly{
+				{Image: "a", Requires: []string{"b"}},
+				{Image: "b", Requires: []string{"c"}},
+			},
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			name: "shared",
+			assemblies: []*api.Assembly{
+				{Image: "a", Requires: []string{"base"}},
+				{Image: "b", Requires: []string{"base"}},
+			},
+			expected: []string{"base", "a", "b"},
+		},
+		{
+			name: "cycle",
+			assemblies: []*api.Assembly{
+				{Image: "a", Requires: []string{"b"}},
+				{Image: "b", Requires: []string{"a"}},
+			},
+			cycle: true,
+		},
+		{
+			name: "self",
+			assemblies: []*api.Assembly{
+				{Image: "a", Requires: []string{"a"}},
+			},
+			cycle: true,
+		},
+	}
+
+	for _, c := range cases {
+		sorted, err := NewGraph(c.assemblies).Sort()
+		if c.cycle {
+			if _, ok := err.(*ErrCycle); !ok {
+				t.Errorf("case %s: expected cycle error; received %v", c.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %s error: %s", c.name, err)
+			continue
+		}
+		if v := images(sorted); !reflect.DeepEqual(v, c.expected) {
+			t.Errorf("case %s error; expected %s; received %s", c.name, c.expected, v)
+		}
+	}
+}
+
+func TestNodeAssemblies(t *testing.T) {
+	ml := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				Assemblies: []*api.Assembly{
+					{Image: "app", Requires: []string{"base"}},
+				},
+			},
+			{
+				NodeID: "other",
+				Assemblies: []*api.Assembly{
+					{Image: "other"},
+				},
+			},
+			{
+				Labels: map[string]string{"env": "prod"},
+				Assemblies: []*api.Assembly{
+					{Image: "base", Parameters: map[string]string{"foo": "bar"}},
+				},
+			},
+		},
+	}
+
+	assemblies := NodeAssemblies(ml, "node", map[string]string{"env": "prod"})
+	if v := images(assemblies); !reflect.DeepEqual(v, []string{"app", "base"}) {
+		t.Fatalf("unexpected assemblies %s", v)
+	}
+	if assemblies[1].Parameters["foo"] != "bar" {
+		t.Fatalf("expected required assembly definition to be used; received %+v", assemblies[1])
+	}
+}
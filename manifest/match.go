@@ -0,0 +1,216 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// FactLabelPrefix is the reserved prefix of the labels agents set from the
+// collected node facts (e.g. terra.io/arch)
+const FactLabelPrefix = "terra.io/"
+
+// Matches returns true if the manifest targets the node with the specified id
+// and labels
+func Matches(m *api.Manifest, nodeID string, labels map[string]string) bool {
+	_, ok := Match(m, nodeID, labels)
+	return ok
+}
+
+// Match returns the rule of the manifest matching the node and true if the
+// manifest targets the node.  excluded nodes never match.  manifests with a
+// selector match if any node id, when set, and every selector term match.
+// manifests without a selector use the deprecated labels matching.
+func Match(m *api.Manifest, nodeID string, labels map[string]string) (string, bool) {
+	for _, pattern := range m.Exclude {
+		if ok, _ := matchNodeID(pattern, nodeID); ok {
+			return "", false
+		}
+	}
+	ids := nodeIDs(m)
+	idRule := ""
+	for _, pattern := range ids {
+		if ok, _ := matchNodeID(pattern, nodeID); ok {
+			idRule = "node " + pattern
+			break
+		}
+	}
+	if m.Selector != "" {
+		if len(ids) > 0 && idRule == "" {
+			return "", false
+		}
+		selector, err := ParseSelector(m.Selector)
+		if err != nil || !selector.Matches(labels) {
+			return "", false
+		}
+		rule := "selector " + selector.String()
+		if idRule != "" {
+			rule = idRule + ", " + rule
+		}
+		return rule, true
+	}
+	return matchLabels(m, idRule, ids, labels)
+}
+
+// matchLabels matches if the node id matches or any of the manifest labels
+// match.  a node label without a value matches any manifest value.
+func matchLabels(m *api.Manifest, idRule string, ids []string, labels map[string]string) (string, bool) {
+	if len(ids) == 0 && len(m.Labels) == 0 {
+		return "all nodes", true
+	}
+	if idRule != "" {
+		return idRule, true
+	}
+	keys := make([]string, 0, len(m.Labels))
+	for k := range m.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if x, ok := labels[k]; ok {
+			if x == "" || x == m.Labels[k] {
+				return "label " + k + "=" + m.Labels[k], true
+			}
+		}
+	}
+	return "", false
+}
+
+// nodeIDs returns the node id and node id patterns of the manifest
+func nodeIDs(m *api.Manifest) []string {
+	ids := m.NodeIDs
+	if m.NodeID != "" {
+		ids = append([]string{m.NodeID}, ids...)
+	}
+	return ids
+}
+
+// ValidateTargets returns an error if a manifest selector, label or node
+// pattern is invalid or a manifest sets both a selector and the deprecated labels
+func ValidateTargets(ml *api.ManifestList) error {
+	if ml == nil {
+		return nil
+	}
+	v := &validator{}
+	for i, m := range ml.Manifests {
+		v.targets(fmt.Sprintf("manifests[%d]", i), m)
+	}
+	return v.errs.Err()
+}
+
+// Migrate returns the manifest list with the deprecated labels of each
+// manifest replaced by selectors matching the same nodes.  as the labels of a
+// manifest are alternatives, a manifest with node ids or several labels is
+// split into a manifest for the node ids and for each label.  nodes with a
+// label without a value are no longer matched by every label value.
+func Migrate(ml *api.ManifestList) *api.ManifestList {
+	migrated := *ml
+	migrated.Manifests = []*api.Manifest{}
+	for _, m := range ml.Manifests {
+		if m.Selector != "" || len(m.Labels) == 0 {
+			migrated.Manifests = append(migrated.Manifests, m)
+			continue
+		}
+		if len(nodeIDs(m)) > 0 {
+			n := *m
+			n.Labels = nil
+			migrated.Manifests = append(migrated.Manifests, &n)
+		}
+		keys := make([]string, 0, len(m.Labels))
+		for k := range m.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			n := *m
+			n.NodeID = ""
+			n.NodeIDs = nil
+			n.Labels = nil
+			n.Selector = LabelSelector(map[string]string{k: m.Labels[k]})
+			migrated.Manifests = append(migrated.Manifests, &n)
+		}
+	}
+	return &migrated
+}
+
+// NodeAssemblies returns the assemblies, including required assemblies, from
+// all manifests in the list that target the node.  each image is returned once
+// in the order it first appears in the list.
+func NodeAssemblies(ml *api.ManifestList, nodeID string, labels map[string]string) []*api.Assembly {
+	assemblies, _ := nodeAssemblies(ml, nodeID, labels)
+	return assemblies
+}
+
+// AssemblyRules returns the rule of the manifest that selected each assembly
+// returned by NodeAssemblies for the node by image
+func AssemblyRules(ml *api.ManifestList, nodeID string, labels map[string]string) map[string]string {
+	_, rules := nodeAssemblies(ml, nodeID, labels)
+	return rules
+}
+
+func nodeAssemblies(ml *api.ManifestList, nodeID string, labels map[string]string) ([]*api.Assembly, map[string]string) {
+	assemblies := []*api.Assembly{}
+	rules := map[string]string{}
+	if ml == nil {
+		return assemblies, rules
+	}
+	seen := map[string]*api.Assembly{}
+	required := map[string]bool{}
+	for i, m := range ml.Manifests {
+		rule, ok := Match(m, nodeID, labels)
+		if !ok {
+			continue
+		}
+		rule = fmt.Sprintf("manifest %d: %s", i, rule)
+		for _, assembly := range m.Assemblies {
+			if existing, ok := seen[assembly.Image]; ok {
+				// prefer the full definition over a bare required image
+				if required[assembly.Image] {
+					*existing = *assembly
+					inherit(existing, m)
+					rules[assembly.Image] = rule
+					delete(required, assembly.Image)
+				}
+			} else {
+				a := *assembly
+				inherit(&a, m)
+				seen[assembly.Image] = &a
+				rules[assembly.Image] = rule
+				assemblies = append(assemblies, &a)
+			}
+			for _, req := range assembly.Requires {
+				if _, ok := seen[req]; ok {
+					continue
+				}
+				r := &api.Assembly{Image: req}
+				seen[req] = r
+				rules[req] = rule
+				required[req] = true
+				assemblies = append(assemblies, r)
+			}
+		}
+	}
+	return assemblies, rules
+}
+
+// inherit sets the manifest retry and execution policies on assemblies without
+// their own and merges the manifest variables into the assembly variables
+func inherit(assembly *api.Assembly, m *api.Manifest) {
+	if assembly.Retry == nil {
+		assembly.Retry = m.Retry
+	}
+	if assembly.Policy == nil {
+		assembly.Policy = m.Policy
+	}
+	if len(m.Variables) > 0 {
+		vars := make(map[string]string, len(m.Variables)+len(assembly.Variables))
+		for k, v := range m.Variables {
+			vars[k] = v
+		}
+		for k, v := range assembly.Variables {
+			vars[k] = v
+		}
+		assembly.Variables = vars
+	}
+}
@@ -0,0 +1,91 @@
+package manifest
+
+import (
+	"testing"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func TestMatchNodeIDs(t *testing.T) {
+	m := &api.Manifest{
+		NodeIDs: []string{"db-1", "web-*", "/^cache-[0-9]+$/"},
+		Exclude: []string{"web-canary", "/-old$/"},
+	}
+	cases := []struct {
+		nodeID string
+		rule   string
+		ok     bool
+	}{
+		{"db-1", "node db-1", true},
+		{"db-2", "", false},
+		{"web-1", "node web-*", true},
+		{"web-canary", "", false},
+		{"web-1-old", "", false},
+		{"cache-12", "node /^cache-[0-9]+$/", true},
+		{"cache-a", "", false},
+	}
+	for _, c := range cases {
+		rule, ok := Match(m, c.nodeID, nil)
+		if ok != c.ok || rule != c.rule {
+			t.Errorf("%s: expected (%q, %v); received (%q, %v)", c.nodeID, c.rule, c.ok, rule, ok)
+		}
+	}
+}
+
+func TestMatchRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		manifest *api.Manifest
+		rule     string
+	}{
+		{"all", &api.Manifest{}, "all nodes"},
+		{"node id", &api.Manifest{NodeID: "node-1", Labels: map[string]string{"env": "prod"}}, "node node-1"},
+		{"label", &api.Manifest{NodeID: "node-2", Labels: map[string]string{"env": "prod"}}, "label env=prod"},
+		{"selector", &api.Manifest{Selector: "env = prod"}, "selector env=prod"},
+		{"node and selector", &api.Manifest{NodeIDs: []string{"node-*"}, Selector: "env"}, "node node-*, selector env"},
+	}
+	for _, c := range cases {
+		rule, ok := Match(c.manifest, "node-1", map[string]string{"env": "prod"})
+		if !ok || rule != c.rule {
+			t.Errorf("%s: expected rule %q; received (%q, %v)", c.name, c.rule, rule, ok)
+		}
+	}
+}
+
+func TestAssemblyRules(t *testing.T) {
+	ml := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				NodeIDs:    []string{"web-*"},
+				Assemblies: []*api.Assembly{{Image: "app", Requires: []string{"base"}}},
+			},
+			{
+				Selector:   "env=prod",
+				Assemblies: []*api.Assembly{{Image: "base"}, {Image: "app"}},
+			},
+		},
+	}
+	rules := AssemblyRules(ml, "web-1", map[string]string{"env": "prod"})
+	expected := map[string]string{
+		"app":  "manifest 0: node web-*",
+		"base": "manifest 1: selector env=prod",
+	}
+	for image, rule := range expected {
+		if rules[image] != rule {
+			t.Errorf("%s: expected rule %q; received %q", image, rule, rules[image])
+		}
+	}
+}
+
+func TestValidateTargets(t *testing.T) {
+	for _, m := range []*api.Manifest{
+		{NodeIDs: []string{"web-["}},
+		{Exclude: []string{"/(/"}},
+		{Selector: "env in ()"},
+		{Selector: "env=prod", Labels: map[string]string{"env": "prod"}},
+	} {
+		if err := ValidateTargets(&api.ManifestList{Manifests: []*api.Manifest{m}}); err == nil {
+			t.Errorf("expected error validating %v", m)
+		}
+	}
+}
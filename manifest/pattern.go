@@ -0,0 +1,24 @@
+package manifest
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// matchNodeID returns true if the node id matches the pattern.  patterns are
+// exact ids, glob patterns (e.g. web-*) or regular expressions between slashes
+// (e.g. /^web-[0-9]+$/).
+func matchNodeID(pattern, nodeID string) (bool, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(nodeID), nil
+	}
+	if !strings.ContainsAny(pattern, "*?[") {
+		return pattern == nodeID, nil
+	}
+	return path.Match(pattern, nodeID)
+}
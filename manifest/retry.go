@@ -0,0 +1,74 @@
+package manifest
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+const (
+	defaultRetryAttempts   = 3
+	defaultRetryBackoff    = 10 * time.Second
+	defaultRetryMaxBackoff = 5 * time.Minute
+)
+
+// Retry is a parsed retry policy
+type Retry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	Jitter      float64
+}
+
+// ParseRetry returns the parsed retry policy with defaults applied.  nil is
+// returned if no policy is specified.
+func ParseRetry(p *api.RetryPolicy) (*Retry, error) {
+	if p == nil {
+		return nil, nil
+	}
+	r := &Retry{
+		MaxAttempts: int(p.MaxAttempts),
+		Backoff:     defaultRetryBackoff,
+		MaxBackoff:  defaultRetryMaxBackoff,
+		Jitter:      p.Jitter,
+	}
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = defaultRetryAttempts
+	}
+	if p.Backoff != "" {
+		d, err := time.ParseDuration(p.Backoff)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid retry backoff")
+		}
+		r.Backoff = d
+	}
+	if p.MaxBackoff != "" {
+		d, err := time.ParseDuration(p.MaxBackoff)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid retry max backoff")
+		}
+		r.MaxBackoff = d
+	}
+	if r.Backoff <= 0 || r.MaxBackoff <= 0 {
+		return nil, errors.New("retry backoff must be positive")
+	}
+	if r.Jitter < 0 || r.Jitter > 1 {
+		return nil, errors.Errorf("retry jitter %v must be between 0 and 1", r.Jitter)
+	}
+	return r, nil
+}
+
+// Delay returns the delay before the next attempt after the specified number
+// of failed attempts.  the backoff doubles each attempt up to the max backoff
+// and is then randomized by the jitter using rnd in [0, 1).
+func (r *Retry) Delay(attempts int, rnd float64) time.Duration {
+	d := r.Backoff
+	for i := 1; i < attempts && d < r.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.MaxBackoff {
+		d = r.MaxBackoff
+	}
+	return d + time.Duration(float64(d)*r.Jitter*(2*rnd-1))
+}
@@ -0,0 +1,57 @@
+package manifest
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func TestRetryDelay(t *testing.T) {
+	r, err := ParseRetry(&api.RetryPolicy{
+		Backoff:    "1s",
+		MaxBackoff: "5s",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.MaxAttempts != defaultRetryAttempts {
+		t.Fatalf("expected default max attempts %d; received %d", defaultRetryAttempts, r.MaxAttempts)
+	}
+
+	cases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{100, 5 * time.Second},
+	}
+	for _, c := range cases {
+		if d := r.Delay(c.attempts, 0.5); d != c.expected {
+			t.Errorf("attempt %d: expected %s; received %s", c.attempts, c.expected, d)
+		}
+	}
+
+	r.Jitter = 0.5
+	if d := r.Delay(1, 0); d != 500*time.Millisecond {
+		t.Errorf("expected minimum jitter delay 500ms; received %s", d)
+	}
+	if d := r.Delay(1, 0.999); d > 1500*time.Millisecond || d < 1400*time.Millisecond {
+		t.Errorf("expected maximum jitter delay near 1.5s; received %s", d)
+	}
+}
+
+func TestParseRetryInvalid(t *testing.T) {
+	for _, p := range []*api.RetryPolicy{
+		{Backoff: "soon"},
+		{MaxBackoff: "-1s"},
+		{Jitter: 2},
+	} {
+		if _, err := ParseRetry(p); err == nil {
+			t.Errorf("expected error for %+v", p)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package manifest
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type operator string
+
+const (
+	opEquals       operator = "="
+	opNotEquals    operator = "!="
+	opIn           operator = "in"
+	opNotIn        operator = "notin"
+	opExists       operator = "exists"
+	opDoesNotExist operator = "!"
+)
+
+var (
+	setTerm = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+	keyExpr = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+)
+
+// requirement is a single term of a selector
+type requirement struct {
+	key    string
+	op     operator
+	values []string
+}
+
+// Selector selects nodes by labels.  a node matches if it matches every term.
+type Selector []requirement
+
+// ParseSelector parses a comma separated list of terms:
+//
+//	key=value, key!=value
+//	key in (a,b), key notin (a,b)
+//	key, !key
+//
+// an empty selector matches every node.
+func ParseSelector(s string) (Selector, error) {
+	var selector Selector
+	for _, term := range splitTerms(s) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		r, err := parseRequirement(term)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid selector term %q", term)
+		}
+		selector = append(selector, r)
+	}
+	return selector, nil
+}
+
+// splitTerms splits the selector on commas outside of value sets
+func splitTerms(s string) []string {
+	var (
+		terms []string
+		depth int
+		start int
+	)
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, s[start:])
+}
+
+func parseRequirement(term string) (requirement, error) {
+	var r requirement
+	switch {
+	case strings.HasPrefix(term, "!") && !strings.Contains(term, "="):
+		r = requirement{key: strings.TrimSpace(term[1:]), op: opDoesNotExist}
+	case strings.Contains(term, "!="):
+		kv := strings.SplitN(term, "!=", 2)
+		r = requirement{key: strings.TrimSpace(kv[0]), op: opNotEquals, values: []string{strings.TrimSpace(kv[1])}}
+	case strings.Contains(term, "="):
+		kv := strings.SplitN(term, "=", 2)
+		value := strings.TrimPrefix(kv[1], "=")
+		r = requirement{key: strings.TrimSpace(kv[0]), op: opEquals, values: []string{strings.TrimSpace(value)}}
+	case setTerm.MatchString(term):
+		m := setTerm.FindStringSubmatch(term)
+		r = requirement{key: m[1], op: operator(m[2])}
+		for _, v := range strings.Split(m[3], ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				r.values = append(r.values, v)
+			}
+		}
+		if len(r.values) == 0 {
+			return r, errors.New("empty value set")
+		}
+		sort.Strings(r.values)
+	default:
+		r = requirement{key: term, op: opExists}
+	}
+	if !keyExpr.MatchString(r.key) {
+		return r, errors.Errorf("invalid key %q", r.key)
+	}
+	for _, v := range r.values {
+		if strings.ContainsAny(v, " ,()=!") {
+			return r, errors.Errorf("invalid value %q", v)
+		}
+	}
+	return r, nil
+}
+
+// Matches returns true if the labels match every term of the selector
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r requirement) matches(labels map[string]string) bool {
+	v, ok := labels[r.key]
+	switch r.op {
+	case opEquals:
+		return ok && v == r.values[0]
+	case opNotEquals:
+		return !ok || v != r.values[0]
+	case opIn:
+		return ok && contains(r.values, v)
+	case opNotIn:
+		return !ok || !contains(r.values, v)
+	case opExists:
+		return ok
+	case opDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the selector in its canonical form
+func (s Selector) String() string {
+	terms := []string{}
+	for _, r := range s {
+		switch r.op {
+		case opEquals, opNotEquals:
+			terms = append(terms, r.key+string(r.op)+r.values[0])
+		case opIn, opNotIn:
+			terms = append(terms, r.key+" "+string(r.op)+" ("+strings.Join(r.values, ",")+")")
+		case opExists:
+			terms = append(terms, r.key)
+		case opDoesNotExist:
+			terms = append(terms, "!"+r.key)
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// LabelSelector returns the selector requiring all of the labels
+func LabelSelector(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	terms := []string{}
+	for _, k := range keys {
+		terms = append(terms, k+"="+labels[k])
+	}
+	return strings.Join(terms, ",")
+}
+
+// negate returns the requirement matching the labels the requirement does not
+func (r requirement) negate() requirement {
+	n := requirement{key: r.key, values: r.values}
+	switch r.op {
+	case opEquals:
+		n.op = opNotEquals
+	case opNotEquals:
+		n.op = opEquals
+	case opIn:
+		n.op = opNotIn
+	case opNotIn:
+		n.op = opIn
+	case opExists:
+		n.op = opDoesNotExist
+	case opDoesNotExist:
+		n.op = opExists
+	}
+	return n
+}
+
+// satisfiable returns false if no labels can match every term of the selector
+func (s Selector) satisfiable() bool {
+	type constraint struct {
+		present, absent bool
+		// allowed is nil if any value is allowed
+		allowed  map[string]bool
+		excluded map[string]bool
+	}
+	constraints := map[string]*constraint{}
+	for _, r := range s {
+		c, ok := constraints[r.key]
+		if !ok {
+			c = &constraint{excluded: map[string]bool{}}
+			constraints[r.key] = c
+		}
+		switch r.op {
+		case opEquals, opIn:
+			c.present = true
+			allowed := map[string]bool{}
+			for _, v := range r.values {
+				if c.allowed == nil || c.allowed[v] {
+					allowed[v] = true
+				}
+			}
+			c.allowed = allowed
+		case opNotEquals, opNotIn:
+			for _, v := range r.values {
+				c.excluded[v] = true
+			}
+		case opExists:
+			c.present = true
+		case opDoesNotExist:
+			c.absent = true
+		}
+	}
+	for _, c := range constraints {
+		if c.present && c.absent {
+			return false
+		}
+		if c.allowed == nil {
+			continue
+		}
+		n := 0
+		for v := range c.allowed {
+			if !c.excluded[v] {
+				n++
+			}
+		}
+		if n == 0 {
+			return false
+		}
+	}
+	return true
+}
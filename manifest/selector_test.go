@@ -0,0 +1,124 @@
+package manifest
+
+import (
+	"testing"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{
+		"env":           "prod",
+		"zone":          "b",
+		"terra.io/arch": "arm64",
+	}
+	cases := []struct {
+		selector string
+		expected bool
+	}{
+		{"", true},
+		{"env=prod", true},
+		{"env==prod", true},
+		{"env=dev", false},
+		{"env!=dev", true},
+		{"missing!=dev", true},
+		{"zone in (a,b)", true},
+		{"zone in (a, c)", false},
+		{"zone notin (a,c)", true},
+		{"missing notin (a)", true},
+		{"missing in (a)", false},
+		{"env", true},
+		{"missing", false},
+		{"!missing", true},
+		{"!env", false},
+		{"env=prod,zone in (a,b),terra.io/arch=arm64,!gpu", true},
+		{"env=prod,zone in (a,c)", false},
+	}
+	for _, c := range cases {
+		s, err := ParseSelector(c.selector)
+		if err != nil {
+			t.Errorf("%q: %s", c.selector, err)
+			continue
+		}
+		if m := s.Matches(labels); m != c.expected {
+			t.Errorf("%q: expected %v; received %v", c.selector, c.expected, m)
+		}
+	}
+}
+
+func TestParseSelectorInvalid(t *testing.T) {
+	for _, s := range []string{"env in ()", "=prod", "env=a b", "zone in (a,b", "-env"} {
+		if _, err := ParseSelector(s); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+}
+
+func TestSelectorString(t *testing.T) {
+	s, err := ParseSelector("env = prod, zone in (b, a),gpu, !spot, os!=windows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "env=prod,zone in (a,b),gpu,!spot,os!=windows"
+	if s.String() != expected {
+		t.Fatalf("expected %q; received %q", expected, s.String())
+	}
+}
+
+func TestMatchesSelector(t *testing.T) {
+	m := &api.Manifest{
+		NodeID:   "node-1",
+		Selector: "env=prod",
+	}
+	if !Matches(m, "node-1", map[string]string{"env": "prod"}) {
+		t.Error("expected node id and selector match")
+	}
+	if Matches(m, "node-1", map[string]string{"env": "dev"}) {
+		t.Error("expected selector mismatch")
+	}
+	if Matches(m, "node-2", map[string]string{"env": "prod"}) {
+		t.Error("expected node id mismatch")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ml := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				NodeID: "node-1",
+				Labels: map[string]string{"env": "prod", "role": "db"},
+			},
+			{
+				Selector: "zone=a",
+			},
+		},
+	}
+	migrated := Migrate(ml)
+	if err := ValidateTargets(migrated); err != nil {
+		t.Fatal(err)
+	}
+	if len(migrated.Manifests) != 4 {
+		t.Fatalf("expected 4 manifests; received %d", len(migrated.Manifests))
+	}
+	nodes := []struct {
+		id     string
+		labels map[string]string
+	}{
+		{"node-1", nil},
+		{"node-2", map[string]string{"env": "prod"}},
+		{"node-3", map[string]string{"role": "db"}},
+		{"node-4", map[string]string{"env": "dev"}},
+	}
+	for _, n := range nodes {
+		var legacy, current bool
+		for _, m := range ml.Manifests[:1] {
+			legacy = legacy || Matches(m, n.id, n.labels)
+		}
+		for _, m := range migrated.Manifests[:3] {
+			current = current || Matches(m, n.id, n.labels)
+		}
+		if legacy != current {
+			t.Errorf("%s: expected migrated match %v; received %v", n.id, legacy, current)
+		}
+	}
+}
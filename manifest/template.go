@@ -0,0 +1,124 @@
+package manifest
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+// Node is a cluster node available to parameter templates
+type Node struct {
+	ID      string
+	Address string
+	Labels  map[string]string
+}
+
+// TemplateData is the data parameter templates are evaluated with
+type TemplateData struct {
+	// Node is the node applying the assembly
+	Node Node
+	// Peers are the other nodes in the cluster
+	Peers []Node
+	// Facts are the facts collected for the node
+	Facts map[string]string
+	// Vars are the manifest and assembly variables
+	Vars map[string]string
+}
+
+var templateFuncs = template.FuncMap{
+	// join takes the separator first so lists can be piped into it
+	"join": func(sep string, elems []string) string {
+		return strings.Join(elems, sep)
+	},
+	// withLabel returns the nodes with the label; any value matches if value is empty
+	"withLabel": func(key, value string, nodes []Node) []Node {
+		matched := []Node{}
+		for _, n := range nodes {
+			if v, ok := n.Labels[key]; ok && (value == "" || v == value) {
+				matched = append(matched, n)
+			}
+		}
+		return matched
+	},
+	// addresses returns the address of each node
+	"addresses": func(nodes []Node) []string {
+		addrs := []string{}
+		for _, n := range nodes {
+			addrs = append(addrs, n.Address)
+		}
+		return addrs
+	},
+	// ids returns the id of each node
+	"ids": func(nodes []Node) []string {
+		ids := []string{}
+		for _, n := range nodes {
+			ids = append(ids, n.ID)
+		}
+		return ids
+	},
+	// default returns def if v is empty; use with index for optional keys
+	"default": func(def, v string) string {
+		if v == "" {
+			return def
+		}
+		return v
+	},
+}
+
+// RenderParameters returns a copy of the assembly with each parameter value
+// evaluated as a template.  referencing a missing label, fact or variable is
+// an error; optional keys can be read with index.
+func RenderParameters(assembly *api.Assembly, data *TemplateData) (*api.Assembly, error) {
+	if len(assembly.Parameters) == 0 {
+		return assembly, nil
+	}
+	d := *data
+	d.Vars = assembly.Variables
+	if d.Vars == nil {
+		d.Vars = map[string]string{}
+	}
+
+	params := make(map[string]string, len(assembly.Parameters))
+	for k, v := range assembly.Parameters {
+		r, err := renderValue(k, v, &d)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: parameter %s", assembly.Image, k)
+		}
+		params[k] = r
+	}
+	rendered := *assembly
+	rendered.Parameters = params
+	return &rendered, nil
+}
+
+// Templated returns true if any assembly parameter is a template
+func Templated(assembly *api.Assembly) bool {
+	for _, v := range assembly.Parameters {
+		if isTemplate(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func renderValue(name, value string, data *TemplateData) (string, error) {
+	if !isTemplate(value) {
+		return value, nil
+	}
+	t, err := template.New(name).Option("missingkey=error").Funcs(templateFuncs).Parse(value)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
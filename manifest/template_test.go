@@ -0,0 +1,76 @@
+package manifest
+
+import (
+	"testing"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func TestRenderParameters(t *testing.T) {
+	data := &TemplateData{
+		Node: Node{
+			ID:     "node-1",
+			Labels: map[string]string{"zone": "a"},
+		},
+		Peers: []Node{
+			{ID: "node-2", Address: "10.0.0.2:9005", Labels: map[string]string{"role": "db"}},
+			{ID: "node-3", Address: "10.0.0.3:9005", Labels: map[string]string{"role": "web"}},
+			{ID: "node-4", Address: "10.0.0.4:9005", Labels: map[string]string{"role": "db"}},
+		},
+		Facts: map[string]string{"arch": "amd64"},
+	}
+	ml := &api.ManifestList{
+		Manifests: []*api.Manifest{
+			{
+				Variables: map[string]string{"env": "prod", "port": "5432"},
+				Assemblies: []*api.Assembly{
+					{
+						Image:     "example/db:latest",
+						Variables: map[string]string{"port": "6432"},
+						Parameters: map[string]string{
+							"zone":    "{{ .Node.Labels.zone }}",
+							"name":    "{{ .Node.ID }}-{{ .Vars.env }}:{{ .Vars.port }}",
+							"peers":   `{{ withLabel "role" "db" .Peers | addresses | join "," }}`,
+							"arch":    "{{ .Facts.arch }}",
+							"static":  "value",
+							"default": `{{ default "none" (index .Vars "missing") }}`,
+						},
+					},
+				},
+			},
+		},
+	}
+	assembly := NodeAssemblies(ml, "node-1", nil)[0]
+	rendered, err := RenderParameters(assembly, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"zone":    "a",
+		"name":    "node-1-prod:6432",
+		"peers":   "10.0.0.2:9005,10.0.0.4:9005",
+		"arch":    "amd64",
+		"static":  "value",
+		"default": "none",
+	}
+	for k, v := range expected {
+		if rendered.Parameters[k] != v {
+			t.Errorf("parameter %s: expected %q; received %q", k, v, rendered.Parameters[k])
+		}
+	}
+	if assembly.Parameters["zone"] != "{{ .Node.Labels.zone }}" {
+		t.Error("expected assembly parameters to be unchanged")
+	}
+}
+
+func TestRenderParametersInvalid(t *testing.T) {
+	for _, v := range []string{"{{ .Node.Labels.missing }}", "{{ .Node.ID", "{{ unknown }}"} {
+		_, err := RenderParameters(&api.Assembly{
+			Image:      "example/app:latest",
+			Parameters: map[string]string{"value": v},
+		}, &TemplateData{})
+		if err == nil {
+			t.Errorf("expected error rendering %q", v)
+		}
+	}
+}
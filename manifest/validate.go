@@ -0,0 +1,266 @@
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/containerd/containerd/reference"
+	"github.com/pkg/errors"
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+const (
+	// SeverityError is an invalid manifest list
+	SeverityError = "error"
+	// SeverityWarning is a manifest list that is valid but likely not intended
+	SeverityWarning = "warning"
+)
+
+// parameters are exported as TERRA_<NAME> and variables are accessed as .Vars.<name>
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidationError is a problem found in a manifest list.  Line and Column are
+// only set when the manifest list was decoded from a file.
+type ValidationError struct {
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Path     string `json:"path"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	var loc []string
+	if e.File != "" {
+		loc = append(loc, e.File)
+	}
+	if e.Line > 0 {
+		loc = append(loc, strconv.Itoa(e.Line))
+	}
+	if e.Column > 0 {
+		loc = append(loc, strconv.Itoa(e.Column))
+	}
+	msg := e.Message
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	if e.Severity == SeverityWarning {
+		msg = "warning: " + msg
+	}
+	if len(loc) == 0 {
+		return msg
+	}
+	return strings.Join(loc, ":") + ": " + msg
+}
+
+// ValidationErrors are the problems found in a manifest list
+type ValidationErrors []*ValidationError
+
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(v))
+	for _, e := range v {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Err returns the errors, excluding warnings, or nil if there are none
+func (v ValidationErrors) Err() error {
+	var errs ValidationErrors
+	for _, e := range v {
+		if e.Severity == SeverityError {
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Path:     path,
+		Severity: SeverityError,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) warnf(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Path:     path,
+		Severity: SeverityWarning,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate checks the image references, node targeting, parameter and
+// variable names, templates and policies of the manifest list and detects
+// duplicate and conflicting assemblies
+func Validate(ml *api.ManifestList) ValidationErrors {
+	v := &validator{}
+	if ml == nil {
+		v.errorf("", "manifest list is empty")
+		return v.errs
+	}
+
+	type definition struct {
+		path     string
+		assembly *api.Assembly
+	}
+	var (
+		definitions = map[string]definition{}
+		all         []*api.Assembly
+	)
+	for i, m := range ml.Manifests {
+		path := fmt.Sprintf("manifests[%d]", i)
+		v.targets(path, m)
+		v.retry(path+".retry", m.Retry)
+		v.names(path+".variables", m.Variables)
+
+		images := map[string]bool{}
+		for j, assembly := range m.Assemblies {
+			apath := fmt.Sprintf("%s.assemblies[%d]", path, j)
+			v.assembly(apath, assembly)
+			if images[assembly.Image] {
+				v.errorf(apath+".image", "duplicate assembly %s in manifest", assembly.Image)
+				continue
+			}
+			images[assembly.Image] = true
+
+			if d, ok := definitions[assembly.Image]; ok {
+				if !reflect.DeepEqual(d.assembly, assembly) {
+					v.warnf(apath, "assembly %s conflicts with %s; nodes matching both manifests use the first", assembly.Image, d.path)
+				}
+				continue
+			}
+			definitions[assembly.Image] = definition{path: apath, assembly: assembly}
+			all = append(all, assembly)
+		}
+	}
+	if _, err := NewGraph(all).Sort(); err != nil {
+		v.errorf("manifests", "%s", err)
+	}
+	return v.errs
+}
+
+func (v *validator) targets(path string, m *api.Manifest) {
+	for i, pattern := range m.NodeIDs {
+		if _, err := matchNodeID(pattern, ""); err != nil {
+			v.errorf(fmt.Sprintf("%s.node_ids[%d]", path, i), "invalid node pattern %q: %s", pattern, err)
+		}
+	}
+	for i, pattern := range m.Exclude {
+		if _, err := matchNodeID(pattern, ""); err != nil {
+			v.errorf(fmt.Sprintf("%s.exclude[%d]", path, i), "invalid node pattern %q: %s", pattern, err)
+		}
+	}
+	for _, k := range keys(m.Labels) {
+		if !keyExpr.MatchString(k) {
+			v.errorf(path+".labels."+k, "invalid label key %q", k)
+		}
+	}
+	if m.Selector != "" {
+		if len(m.Labels) > 0 {
+			v.errorf(path+".selector", "labels and selector cannot both be set")
+		}
+		if _, err := ParseSelector(m.Selector); err != nil {
+			v.errorf(path+".selector", "%s", err)
+		}
+	}
+}
+
+func (v *validator) assembly(path string, assembly *api.Assembly) {
+	if assembly.Image == "" {
+		v.errorf(path+".image", "image is required")
+	} else if err := validateImage(assembly.Image); err != nil {
+		v.errorf(path+".image", "%s", err)
+	}
+	for i, req := range assembly.Requires {
+		if err := validateImage(req); err != nil {
+			v.errorf(fmt.Sprintf("%s.requires[%d]", path, i), "%s", err)
+		}
+	}
+	v.names(path+".parameters", assembly.Parameters)
+	v.names(path+".variables", assembly.Variables)
+	// parameters differing in case are exported as the same variable
+	env := map[string]string{}
+	for _, k := range keys(assembly.Parameters) {
+		value := assembly.Parameters[k]
+		ppath := path + ".parameters." + k
+		if other, ok := env[strings.ToUpper(k)]; ok {
+			v.errorf(ppath, "parameter conflicts with %s as TERRA_%s", other, strings.ToUpper(k))
+		}
+		env[strings.ToUpper(k)] = k
+		if isTemplate(value) {
+			if _, err := template.New(k).Funcs(templateFuncs).Parse(value); err != nil {
+				v.errorf(ppath, "invalid template: %s", err)
+			}
+		}
+		if strings.HasPrefix(value, "secret:") && strings.TrimPrefix(value, "secret:") == "" {
+			v.errorf(ppath, "secret name is required")
+		}
+	}
+	if assembly.Timeout != "" {
+		if d, err := time.ParseDuration(assembly.Timeout); err != nil || d <= 0 {
+			v.errorf(path+".timeout", "invalid timeout %q", assembly.Timeout)
+		}
+	}
+	v.retry(path+".retry", assembly.Retry)
+}
+
+func (v *validator) names(path string, values map[string]string) {
+	for _, k := range keys(values) {
+		if !identifier.MatchString(k) {
+			v.errorf(path+"."+k, "invalid name %q; names must be letters, digits and underscores", k)
+		}
+	}
+}
+
+func (v *validator) retry(path string, policy *api.RetryPolicy) {
+	if policy == nil {
+		return
+	}
+	if _, err := ParseRetry(policy); err != nil {
+		v.errorf(path, "%s", err)
+	}
+}
+
+// keys returns the sorted keys so errors are reported in a stable order
+func keys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateImage returns an error if the image is not a valid reference with a
+// tag or digest
+func validateImage(image string) error {
+	spec, err := reference.Parse(image)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image reference %q", image)
+	}
+	if spec.Object == "" {
+		return errors.Errorf("invalid image reference %q: tag or digest required", image)
+	}
+	if dgst := spec.Digest(); dgst != "" {
+		if err := dgst.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid image reference %q", image)
+		}
+	}
+	return nil
+}
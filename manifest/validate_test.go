@@ -0,0 +1,112 @@
+package manifest
+
+import (
+	"testing"
+
+	api "github.com/stellarproject/terra/api/v1"
+)
+
+func TestValidate(t *testing.T) {
+	valid := &api.Assembly{Image: "docker.io/example/base:latest"}
+	cases := []struct {
+		name     string
+		manifest *api.Manifest
+		path     string
+	}{
+		{"missing tag", &api.Manifest{Assemblies: []*api.Assembly{{Image: "docker.io/example/base"}}}, "manifests[0].assemblies[0].image"},
+		{"missing host", &api.Manifest{Assemblies: []*api.Assembly{{Image: "/base:latest"}}}, "manifests[0].assemblies[0].image"},
+		{"invalid digest", &api.Manifest{Assemblies: []*api.Assembly{{Image: "docker.io/example/base@sha256:abc"}}}, "manifests[0].assemblies[0].image"},
+		{"invalid requirement", &api.Manifest{Assemblies: []*api.Assembly{{Image: valid.Image, Requires: []string{"base"}}}}, "manifests[0].assemblies[0].requires[0]"},
+		{"duplicate", &api.Manifest{Assemblies: []*api.Assembly{valid, valid}}, "manifests[0].assemblies[1].image"},
+		{"parameter name", &api.Manifest{Assemblies: []*api.Assembly{{Image: valid.Image, Parameters: map[string]string{"log-level": "debug"}}}}, "manifests[0].assemblies[0].parameters.log-level"},
+		{"template", &api.Manifest{Assemblies: []*api.Assembly{{Image: valid.Image, Parameters: map[string]string{"peers": "{{ .Peers "}}}}, "manifests[0].assemblies[0].parameters.peers"},
+		{"secret", &api.Manifest{Assemblies: []*api.Assembly{{Image: valid.Image, Parameters: map[string]string{"token": "secret:"}}}}, "manifests[0].assemblies[0].parameters.token"},
+		{"timeout", &api.Manifest{Assemblies: []*api.Assembly{{Image: valid.Image, Timeout: "10"}}}, "manifests[0].assemblies[0].timeout"},
+		{"retry", &api.Manifest{Retry: &api.RetryPolicy{Jitter: 2}}, "manifests[0].retry"},
+		{"variable name", &api.Manifest{Variables: map[string]string{"a.b": "c"}}, "manifests[0].variables.a.b"},
+		{"label key", &api.Manifest{Labels: map[string]string{"env ": "prod"}}, "manifests[0].labels.env "},
+		{"cycle", &api.Manifest{Assemblies: []*api.Assembly{{Image: valid.Image, Requires: []string{valid.Image}}}}, "manifests"},
+	}
+	for _, c := range cases {
+		errs := Validate(&api.ManifestList{Manifests: []*api.Manifest{c.manifest}})
+		if errs.Err() == nil {
+			t.Errorf("%s: expected error", c.name)
+			continue
+		}
+		if errs[0].Path != c.path {
+			t.Errorf("%s: expected path %q; received %q (%s)", c.name, c.path, errs[0].Path, errs[0].Message)
+		}
+	}
+}
+
+func TestValidateParameterCase(t *testing.T) {
+	errs := Validate(&api.ManifestList{Manifests: []*api.Manifest{{
+		Assemblies: []*api.Assembly{{
+			Image:      "docker.io/example/base:latest",
+			Parameters: map[string]string{"port": "80", "PORT": "8080"},
+		}},
+	}}})
+	if len(errs) != 1 || errs[0].Severity != SeverityError {
+		t.Fatalf("expected conflicting parameter error; received %v", errs)
+	}
+}
+
+func TestValidateConflict(t *testing.T) {
+	errs := Validate(&api.ManifestList{Manifests: []*api.Manifest{
+		{NodeID: "a", Assemblies: []*api.Assembly{{Image: "docker.io/example/base:latest"}}},
+		{NodeID: "b", Assemblies: []*api.Assembly{{Image: "docker.io/example/base:latest", Timeout: "5m"}}},
+	}})
+	if len(errs) != 1 || errs[0].Severity != SeverityWarning || errs[0].Path != "manifests[1].assemblies[0]" {
+		t.Fatalf("expected conflict warning; received %v", errs)
+	}
+	if errs.Err() != nil {
+		t.Fatalf("expected warnings to be valid; received %s", errs.Err())
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	data := []byte(`{
+  "manifests": [
+    {
+      "node_id": "dev",
+      "assemblies": [
+        {
+          "image": "docker.io/example/base:latest",
+          "paramters": {"a": "b"}
+        },
+        {
+          "image": "docker.io/example/app",
+          "parameters": {"Port": "80"}
+        }
+      ]
+    }
+  ]
+}`)
+	_, errs := DecodeJSON(data)
+	if len(errs) != 2 {
+		t.Fatalf("expected unknown field and image errors; received %v", errs)
+	}
+	if e := errs[0]; e.Line != 8 || e.Column != 11 || e.Path != "manifests[0].assemblies[0].paramters" {
+		t.Errorf("unexpected unknown field error %s", e)
+	}
+	if e := errs[1]; e.Line != 11 || e.Column != 11 || e.Path != "manifests[0].assemblies[1].image" {
+		t.Errorf("unexpected image error %s", e)
+	}
+
+	data = []byte(`{"manifests": [{"node_id": "dev", "assemblies": [
+  {"image": "docker.io/example/base"}]}]}`)
+	ml, errs := DecodeJSON(data)
+	if ml == nil || len(errs) != 1 {
+		t.Fatalf("expected image error; received %v", errs)
+	}
+	if e := errs[0]; e.Line != 2 || e.Column != 4 {
+		t.Errorf("expected error at 2:4; received %s", e)
+	}
+
+	if _, errs := DecodeJSON([]byte("{\n  \"manifests\": [\n}")); errs.Err() == nil || errs[0].Line != 3 {
+		t.Errorf("expected syntax error on line 3; received %v", errs)
+	}
+	if _, errs := DecodeJSON([]byte(`{"manifests": {}}`)); errs.Err() == nil {
+		t.Error("expected type error")
+	}
+}